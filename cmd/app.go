@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/atotto/clipboard"
+	"github.com/spf13/cobra"
+)
+
+// App holds the dependencies shared by every command. Execute builds one with
+// the real implementations and passes it through the command context; tests
+// build their own instead of swapping package state, so they can run in parallel.
+type App struct {
+	// Exec starts the git and gh processes
+	Exec topicurls.CommandRunner
+	// API performs GitHub REST requests; configureTransport wraps it with the cache or fixtures
+	API apiTransport
+	// Clipboard receives the rendered output
+	Clipboard func(text string) error
+	// Stdin answers confirmation prompts
+	Stdin io.Reader
+	// Stdout and Stderr receive the command output
+	Stdout io.Writer
+	Stderr io.Writer
+	// Now is the clock used for release dates and cache ages
+	Now func() time.Time
+	// Getenv reads environment variables such as the CI branch variables
+	Getenv func(key string) string
+	// OpenURL opens a pull request or search page in the browser
+	OpenURL func(url string) error
+	// Notify shows a desktop notification for --watch --notify
+	Notify func(title, message string) error
+	// PickPullRequests lets the user choose which fetched pull requests to output
+	PickPullRequests func(prs []pullRequest) ([]pullRequest, error)
+
+	// GitBackend, Format and WithIssues are set from the persistent flags
+	GitBackend string
+	Format     string
+	WithIssues bool
+	// Pick is set from --pick and enables PickPullRequests in the output step
+	Pick bool
+	// Open is set from --open and opens the output pull requests with OpenURL,
+	// asking first when there are more than OpenLimit
+	Open      bool
+	OpenLimit int
+	// Comment is set from --comment-on, --comment-on-issue and --update-comment
+	// and posts the output as a comment
+	Comment commentOptions
+	// UpdateBody is set from --update-body and --dry-run and replaces the marked
+	// section of a pull request description with the output
+	UpdateBody bodyOptions
+
+	// cache is the response cache wrapped into API, nil when caching is disabled
+	cache *cacheTransport
+	// replay is set by configureTransport when API responses come from recorded fixtures
+	replay bool
+}
+
+// newApp returns an App wired to the real processes, terminal, clipboard and clock
+func newApp() *App {
+	app := &App{
+		Exec:       exec.CommandContext,
+		API:        topicurls.NewGHTransport(),
+		Clipboard:  clipboard.WriteAll,
+		Stdin:      os.Stdin,
+		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
+		Now:        time.Now,
+		Getenv:     os.Getenv,
+		GitBackend: gitBackendExec,
+		Format:     defaultFormat,
+
+		Notify:           desktopNotify,
+		PickPullRequests: runPRPicker,
+	}
+	app.OpenURL = app.openInBrowser
+	return app
+}
+
+type appContextKey struct{}
+
+// withApp returns a context carrying the App used by the commands
+func withApp(ctx context.Context, app *App) context.Context {
+	return context.WithValue(ctx, appContextKey{}, app)
+}
+
+// appFrom returns the App of a command. A command run without one (e.g. directly
+// through cobra) gets a default App, stored so its hooks share it.
+func appFrom(cmd *cobra.Command) *App {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if app, ok := ctx.Value(appContextKey{}).(*App); ok {
+		return app
+	}
+
+	app := newApp()
+	cmd.SetContext(withApp(ctx, app))
+	return app
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// testClock is the fixed time returned by the Now of test Apps
+var testClock = time.Date(2025, 9, 6, 12, 0, 0, 0, time.UTC)
+
+// newTestApp returns an App that runs git and gh through run, writes to the
+// returned buffer and discards clipboard writes
+func newTestApp(run topicurls.CommandRunner) (*App, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &App{
+		Exec:       run,
+		API:        topicurls.GHTransport{Exec: run},
+		Clipboard:  func(string) error { return nil },
+		Stdin:      strings.NewReader(""),
+		Stdout:     &out,
+		Stderr:     &out,
+		Now:        func() time.Time { return testClock },
+		Getenv:     func(string) string { return "" },
+		GitBackend: gitBackendExec,
+		Format:     defaultFormat,
+
+		OpenURL:          func(string) error { return nil },
+		Notify:           func(string, string) error { return nil },
+		PickPullRequests: func(prs []pullRequest) ([]pullRequest, error) { return prs, nil },
+	}, &out
+}
+
+// fakeAPI is the REST transport of the tests. A request is answered by the
+// longest route that its path ends with or contains as a whole query
+// parameter, e.g. an exact path or "base=main". Each route serves its
+// responses in turn and then repeats the last one; a nil response fails like a
+// lost connection. Unmatched paths get a 404, and every request is recorded.
+type fakeAPI struct {
+	routes map[string][]*apiResponse
+	// onRequest is called with the number of requests served so far after each one
+	onRequest func(n int)
+
+	mu       sync.Mutex
+	served   map[string]int
+	requests []fakeRequest
+}
+
+// fakeRequest is a request received by fakeAPI
+type fakeRequest struct {
+	Path   string
+	Header http.Header
+}
+
+// newFakeAPI returns a fakeAPI answering each route with a body
+func newFakeAPI(bodies map[string]string) *fakeAPI {
+	f := &fakeAPI{}
+	for route, body := range bodies {
+		f.on(route, okResponse("", body))
+	}
+	return f
+}
+
+// on sets the responses route serves in turn
+func (f *fakeAPI) on(route string, responses ...*apiResponse) *fakeAPI {
+	if f.routes == nil {
+		f.routes = make(map[string][]*apiResponse)
+	}
+	f.routes[route] = responses
+	return f
+}
+
+func (f *fakeAPI) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Like gh, nothing is sent once the context is done
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, fakeRequest{Path: path, Header: header})
+	if f.onRequest != nil {
+		defer f.onRequest(len(f.requests))
+	}
+
+	match, ok := "", false
+	for route := range f.routes {
+		if strings.Contains(path+"&", route+"&") && len(route) >= len(match) {
+			match, ok = route, true
+		}
+	}
+	if !ok {
+		return &apiResponse{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: []byte(`{"message":"Not Found"}`)}, nil
+	}
+
+	if f.served == nil {
+		f.served = make(map[string]int)
+	}
+	responses := f.routes[match]
+	resp := responses[min(f.served[match], len(responses)-1)]
+	f.served[match]++
+	if resp == nil {
+		return nil, errors.New("connection reset")
+	}
+	return resp, nil
+}
+
+// paths returns the paths of the requests received so far
+func (f *fakeAPI) paths() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paths := make([]string, len(f.requests))
+	for i, req := range f.requests {
+		paths[i] = req.Path
+	}
+	return paths
+}
+
+// header returns the header of the i-th request
+func (f *fakeAPI) header(i int) http.Header {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[i].Header
+}
+
+func okResponse(etag, body string) *apiResponse {
+	header := http.Header{}
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	return &apiResponse{StatusCode: http.StatusOK, Header: header, Body: []byte(body)}
+}
+
+func TestAppFrom(t *testing.T) {
+	t.Parallel()
+
+	// A command run through Execute uses the App from its context
+	app, _ := newTestApp(mockExecCommand("", nil))
+	cmd := &cobra.Command{}
+	cmd.SetContext(withApp(context.Background(), app))
+	assert.Same(t, app, appFrom(cmd))
+
+	// Without one, a default App is created once and reused by later hooks
+	bare := &cobra.Command{}
+	first := appFrom(bare)
+	assert.NotNil(t, first.Exec)
+	assert.Same(t, first, appFrom(bare))
+}
+
+func TestGetTopicUrls(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		mockOutput    string
+		clipboardErr  error
+		expectedOut   string
+		expectedClip  string
+		expectedError bool
+	}{
+		{
+			name:         "Pull requests are printed and copied",
+			mockOutput:   mockAPIOutput(200, `[{"number":1,"html_url":"https://github.com/owner/repo/pull/1"}]`),
+			expectedOut:  "- https://github.com/owner/repo/pull/1\n✨ Copied to clipboard\n",
+			expectedClip: "- https://github.com/owner/repo/pull/1\n",
+		},
+		{
+			name:        "Nothing is copied without pull requests",
+			mockOutput:  mockAPIOutput(200, `[]`),
+			expectedOut: "No pull requests found for branch 'main'\n",
+		},
+		{
+			name:          "Clipboard failures are reported",
+			mockOutput:    mockAPIOutput(200, `[{"number":1,"html_url":"https://github.com/owner/repo/pull/1"}]`),
+			clipboardErr:  fmt.Errorf("no clipboard"),
+			expectedOut:   "- https://github.com/owner/repo/pull/1\n",
+			expectedClip:  "- https://github.com/owner/repo/pull/1\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: git reports owner/repo and gh returns the pull requests
+			app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+			app.API = topicurls.GHTransport{Exec: mockExecCommand(tt.mockOutput, nil)}
+			var clip string
+			app.Clipboard = func(text string) error {
+				clip = text
+				return tt.clipboardErr
+			}
+
+			// Act: List the pull requests into main
+			err := app.getTopicUrls(context.Background(), "main")
+
+			// Assert: Output and clipboard content
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedOut, out.String())
+			assert.Equal(t, tt.expectedClip, clip)
+		})
+	}
+}
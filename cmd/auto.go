@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// topicPatternEnv configures the topic branch naming pattern when --topic-pattern is not given
+const topicPatternEnv = "GH_TOPIC_URLS_TOPIC_PATTERN"
+
+// topicPattern compiles the topic branch naming pattern from the flag or the
+// environment; nil means no pattern is configured
+func (a *App) topicPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = a.Getenv(topicPatternEnv)
+	}
+	if pattern == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic branch pattern: %w", err)
+	}
+	return re, nil
+}
+
+// topicBranchFromName derives a topic branch from a branch name: the first
+// capture group of pattern, or the whole match when it has no groups
+func topicBranchFromName(branch string, pattern *regexp.Regexp) string {
+	match := pattern.FindStringSubmatch(branch)
+	if match == nil {
+		return ""
+	}
+	if len(match) > 1 {
+		return match[1]
+	}
+	return match[0]
+}
+
+// inferBaseBranch finds the branch --auto lists instead of branch: the base of
+// branch's open pull request, or the topic branch its name matches. The second
+// result describes where the branch came from; an empty branch means none was found.
+func (a *App) inferBaseBranch(ctx context.Context, repo, branch string, pattern *regexp.Regexp) (string, string, error) {
+	pr, err := a.client().FindOpenPR(ctx, repo, branch)
+	if err != nil && pattern == nil {
+		return "", "", fmt.Errorf("failed to find the pull request of '%s': %w", branch, err)
+	}
+	if pr != nil && pr.Base.Ref != "" {
+		return pr.Base.Ref, fmt.Sprintf("the base of #%d", pr.Number), nil
+	}
+
+	if pattern != nil {
+		if topic := topicBranchFromName(branch, pattern); topic != "" && topic != branch {
+			return topic, fmt.Sprintf("matched by the topic branch pattern %s", pattern), nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// getAutoTopicUrls lists the pull requests into branchName or, when there are
+// none, into the base branch inferred from it
+func (a *App) getAutoTopicUrls(ctx context.Context, branchName string, pattern *regexp.Regexp) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	prs, err := a.fetchPullRequests(ctx, repo, branchName)
+	if err != nil {
+		return err
+	}
+
+	if len(prs) == 0 {
+		base, reason, err := a.inferBaseBranch(ctx, repo, branchName, pattern)
+		if err != nil {
+			return err
+		}
+		if base == "" {
+			fmt.Fprintf(a.Stderr, "No pull requests found for branch '%s' and no base branch could be inferred\n", branchName)
+			return nil
+		}
+
+		fmt.Fprintf(a.Stderr, "No pull requests into '%s'; listing '%s', %s\n", branchName, base, reason)
+		branchName = base
+		prs, err = a.fetchPullRequests(ctx, repo, branchName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(prs) == 0 {
+		fmt.Fprintf(a.Stderr, "No pull requests found for branch '%s'\n", branchName)
+		return nil
+	}
+
+	return a.outputPullRequests(ctx, repo, prs)
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicBranchFromName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pattern  string
+		branch   string
+		expected string
+	}{
+		{name: "Capture group", pattern: `^(feature/[^/]+)/`, branch: "feature/payments/refunds", expected: "feature/payments"},
+		{name: "Whole match without groups", pattern: `^release/[0-9.]+`, branch: "release/1.2-hotfix", expected: "release/1.2"},
+		{name: "No match", pattern: `^(feature/[^/]+)/`, branch: "fix/typo", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, topicBranchFromName(tt.branch, regexp.MustCompile(tt.pattern)))
+		})
+	}
+}
+
+func TestGetAutoTopicUrls(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		routes        map[string]string
+		pattern       string
+		expectedOut   string
+		expectedError bool
+	}{
+		{
+			name: "Pull requests into the current branch are listed directly",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds": `[{"number":7,"html_url":"https://github.com/owner/repo/pull/7"}]`,
+			},
+			expectedOut: "- https://github.com/owner/repo/pull/7\n✨ Copied to clipboard\n",
+		},
+		{
+			name: "Base of the open pull request",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds":         `[]`,
+				"head=owner%3Afeature%2Fpayments%2Frefunds": `[{"number":42,"base":{"ref":"release/next"}}]`,
+				"base=release%2Fnext":                       `[{"number":3,"html_url":"https://github.com/owner/repo/pull/3"}]`,
+			},
+			expectedOut: "No pull requests into 'feature/payments/refunds'; listing 'release/next', the base of #42\n" +
+				"- https://github.com/owner/repo/pull/3\n✨ Copied to clipboard\n",
+		},
+		{
+			name: "Topic pattern without an open pull request",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds":         `[]`,
+				"head=owner%3Afeature%2Fpayments%2Frefunds": `[]`,
+				"base=feature%2Fpayments":                   `[{"number":5,"html_url":"https://github.com/owner/repo/pull/5"}]`,
+			},
+			pattern: `^(feature/[^/]+)/`,
+			expectedOut: "No pull requests into 'feature/payments/refunds'; listing 'feature/payments', matched by the topic branch pattern ^(feature/[^/]+)/\n" +
+				"- https://github.com/owner/repo/pull/5\n✨ Copied to clipboard\n",
+		},
+		{
+			name: "Topic pattern when the lookup fails",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds": `[]`,
+				"base=feature%2Fpayments":           `[]`,
+			},
+			pattern:     `^(feature/[^/]+)/`,
+			expectedOut: "No pull requests into 'feature/payments/refunds'; listing 'feature/payments', matched by the topic branch pattern ^(feature/[^/]+)/\nNo pull requests found for branch 'feature/payments'\n",
+		},
+		{
+			name: "Nothing to infer",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds":         `[]`,
+				"head=owner%3Afeature%2Fpayments%2Frefunds": `[]`,
+			},
+			expectedOut: "No pull requests found for branch 'feature/payments/refunds' and no base branch could be inferred\n",
+		},
+		{
+			name: "Lookup failure without a pattern",
+			routes: map[string]string{
+				"base=feature%2Fpayments%2Frefunds": `[]`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: The current branch is feature/payments/refunds of owner/repo
+			app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+			app.API = newFakeAPI(tt.routes)
+			var pattern *regexp.Regexp
+			if tt.pattern != "" {
+				pattern = regexp.MustCompile(tt.pattern)
+			}
+
+			// Act: List the pull requests, inferring the base when needed
+			err := app.getAutoTopicUrls(context.Background(), "feature/payments/refunds", pattern)
+
+			// Assert: The note and the listed pull requests
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
+
+func TestTopicPattern(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand("", nil))
+	app.Getenv = func(key string) string {
+		if key == topicPatternEnv {
+			return `^(feature/[^/]+)/`
+		}
+		return ""
+	}
+
+	// The flag takes precedence over the environment
+	pattern, err := app.topicPattern(`^release/`)
+	assert.NoError(t, err)
+	assert.Equal(t, `^release/`, pattern.String())
+
+	// The environment is used without the flag
+	pattern, err = app.topicPattern("")
+	assert.NoError(t, err)
+	assert.Equal(t, `^(feature/[^/]+)/`, pattern.String())
+
+	// Invalid patterns are reported
+	_, err = app.topicPattern(`(`)
+	assert.ErrorContains(t, err, "invalid topic branch pattern")
+}
+
+func TestAutoRejectsExplicitBranches(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand("", nil))
+
+	for _, opts := range []rootOptions{
+		{Auto: true, TopicBranch: "release/next"},
+		{Auto: true, Interactive: true},
+		{Auto: true, FromCommits: "main..HEAD"},
+		{Auto: true, Org: "owner"},
+	} {
+		err := app.runTopicUrls(context.Background(), opts, nil)
+		assert.ErrorContains(t, err, "--auto cannot be combined", "%+v", opts)
+	}
+}
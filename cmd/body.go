@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Markers delimiting the section of a pull request description replaced by --update-body
+const (
+	bodyStartMarker = "<!-- topic-urls:start -->"
+	bodyEndMarker   = "<!-- topic-urls:end -->"
+)
+
+// bodyOptions are set from --update-body and --dry-run
+type bodyOptions struct {
+	// Number is the pull request of the current repository whose description
+	// is updated; 0 disables the update
+	Number int
+	// DryRun prints the change as a diff instead of applying it
+	DryRun bool
+}
+
+// replaceMarkedSection returns body with the text between bodyStartMarker and
+// bodyEndMarker replaced by content, keeping the markers and the rest of body
+func replaceMarkedSection(body, content string) (string, error) {
+	start := strings.Index(body, bodyStartMarker)
+	if start < 0 {
+		return "", fmt.Errorf("no %s marker found", bodyStartMarker)
+	}
+	start += len(bodyStartMarker)
+
+	end := strings.Index(body[start:], bodyEndMarker)
+	if end < 0 {
+		return "", fmt.Errorf("no %s marker found after %s", bodyEndMarker, bodyStartMarker)
+	}
+	end += start
+
+	// Descriptions edited on GitHub use CRLF line endings; keep them consistent
+	newline := "\n"
+	if strings.Contains(body, "\r\n") {
+		newline = "\r\n"
+	}
+	content = strings.TrimSuffix(content, "\n")
+	content = strings.ReplaceAll(content, "\n", newline)
+
+	return body[:start] + newline + content + newline + body[end:], nil
+}
+
+// updatePullRequestBody replaces the marked section of the description of the
+// pull request of a.UpdateBody with the rendered output, or prints the change with --dry-run
+func (a *App) updatePullRequestBody(ctx context.Context, output string) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	number := a.UpdateBody.Number
+	path := fmt.Sprintf("/repos/%s/pulls/%d", repo, number)
+	data, err := a.ghAPIFresh(ctx, path)
+	if err != nil {
+		return fmt.Errorf("pull request #%d not found in %s: %w", number, repo, err)
+	}
+	var pr pullRequest
+	if err := json.Unmarshal(data, &pr); err != nil {
+		return fmt.Errorf("failed to decode pull request: %w", err)
+	}
+
+	updated, err := replaceMarkedSection(pr.Body, markdownBlock(a.Format, output))
+	if err != nil {
+		return fmt.Errorf("cannot update the description of #%d: %w", number, err)
+	}
+	if updated == pr.Body {
+		fmt.Fprintf(a.Stderr, "📝 The description of #%d is up to date\n", number)
+		return nil
+	}
+
+	if a.UpdateBody.DryRun {
+		name := fmt.Sprintf("#%d description", number)
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(strings.ReplaceAll(pr.Body, "\r\n", "\n")),
+			B:        difflib.SplitLines(strings.ReplaceAll(updated, "\r\n", "\n")),
+			FromFile: name,
+			ToFile:   name,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to compute diff: %w", err)
+		}
+		fmt.Fprint(a.Stdout, diff)
+		return nil
+	}
+
+	if _, err := a.ghAPIWrite(ctx, "PATCH", path, map[string]string{"body": updated}); err != nil {
+		return fmt.Errorf("failed to update the description of #%d: %w", number, err)
+	}
+	fmt.Fprintf(a.Stderr, "📝 Updated the description of #%d\n", number)
+	return nil
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceMarkedSection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		body          string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "Empty section",
+			body:     "## Included PRs\n<!-- topic-urls:start --><!-- topic-urls:end -->\n\nThanks!",
+			expected: "## Included PRs\n<!-- topic-urls:start -->\n- url\n<!-- topic-urls:end -->\n\nThanks!",
+		},
+		{
+			name:     "Previous list is replaced",
+			body:     "Intro\n<!-- topic-urls:start -->\n- old\n- older\n<!-- topic-urls:end -->\nOutro",
+			expected: "Intro\n<!-- topic-urls:start -->\n- url\n<!-- topic-urls:end -->\nOutro",
+		},
+		{
+			name:     "CRLF line endings are kept",
+			body:     "Intro\r\n<!-- topic-urls:start -->\r\n- old\r\n<!-- topic-urls:end -->\r\n",
+			expected: "Intro\r\n<!-- topic-urls:start -->\r\n- url\r\n<!-- topic-urls:end -->\r\n",
+		},
+		{
+			name:          "Missing start marker",
+			body:          "Intro\n<!-- topic-urls:end -->",
+			expectedError: "no <!-- topic-urls:start --> marker found",
+		},
+		{
+			name:          "End marker before the start marker",
+			body:          "<!-- topic-urls:end -->\n<!-- topic-urls:start -->",
+			expectedError: "no <!-- topic-urls:end --> marker found after <!-- topic-urls:start -->",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			updated, err := replaceMarkedSection(tt.body, "- url\n")
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, updated)
+		})
+	}
+}
+
+func TestUpdatePullRequestBody(t *testing.T) {
+	t.Parallel()
+
+	const pullPath = "/repos/owner/repo/pulls/42"
+	prBody := func(body string) string {
+		data, _ := json.Marshal(map[string]any{"number": 42, "body": body})
+		return string(data)
+	}
+
+	tests := []struct {
+		name          string
+		body          string
+		dryRun        bool
+		expectedCalls []string
+		expectedOut   string
+		expectedError string
+	}{
+		{
+			name:          "Updates the section",
+			body:          "Release\n<!-- topic-urls:start -->\n- old\n<!-- topic-urls:end -->\n",
+			expectedCalls: []string{"api --method PATCH /repos/owner/repo/pulls/42 -f body=Release\n<!-- topic-urls:start -->\n- https://github.com/owner/repo/pull/7\n<!-- topic-urls:end -->\n"},
+			expectedOut:   "📝 Updated the description of #42\n",
+		},
+		{
+			name:   "Dry run prints a diff",
+			body:   "Release\n<!-- topic-urls:start -->\n- old\n<!-- topic-urls:end -->\n",
+			dryRun: true,
+			expectedOut: "--- #42 description\n+++ #42 description\n@@ -1,5 +1,5 @@\n Release\n <!-- topic-urls:start -->\n" +
+				"-- old\n+- https://github.com/owner/repo/pull/7\n <!-- topic-urls:end -->\n \n",
+		},
+		{
+			name:        "Nothing to change",
+			body:        "<!-- topic-urls:start -->\n- https://github.com/owner/repo/pull/7\n<!-- topic-urls:end -->",
+			expectedOut: "📝 The description of #42 is up to date\n",
+		},
+		{
+			name:          "Description without markers",
+			body:          "Release notes",
+			expectedError: "cannot update the description of #42: no <!-- topic-urls:start --> marker found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: PR #42 has the given description
+			recorder := &ghWriteRecorder{output: `{"number":42}`}
+			app, out := newTestApp(recorder.run)
+			app.API = newFakeAPI(map[string]string{pullPath: prBody(tt.body)})
+			app.UpdateBody = bodyOptions{Number: 42, DryRun: tt.dryRun}
+
+			// Act: Update the marked section with the rendered output
+			err := app.updatePullRequestBody(context.Background(), "- https://github.com/owner/repo/pull/7\n")
+
+			// Assert: The description is patched only when it changes and not in a dry run
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, recorder.calls)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
+
+func TestUpdatePullRequestBodyRevalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A fresh cache entry holds an outdated description of #42
+	const pullPath = "/repos/owner/repo/pulls/42"
+	recorder := &ghWriteRecorder{output: `{"number":42}`}
+	app, out := newTestApp(recorder.run)
+	next := (&fakeAPI{}).on(pullPath,
+		okResponse(`"v1"`, `{"number":42,"body":"Old\n<!-- topic-urls:start -->\n<!-- topic-urls:end -->\n"}`),
+		okResponse(`"v2"`, `{"number":42,"body":"Edited\n<!-- topic-urls:start -->\n<!-- topic-urls:end -->\n"}`))
+	cache := newCacheTransport(next, t.TempDir(), time.Hour)
+	cache.now = app.Now
+	_, err := cache.Get(context.Background(), pullPath, nil)
+	require.NoError(t, err)
+	app.API = cache
+	app.UpdateBody = bodyOptions{Number: 42}
+
+	// Act: Update the marked section within the TTL
+	err = app.updatePullRequestBody(context.Background(), "- https://github.com/owner/repo/pull/7\n")
+
+	// Assert: The description is revalidated and the edit made since is kept
+	require.NoError(t, err)
+	assert.Len(t, next.paths(), 2)
+	assert.Equal(t, `"v1"`, next.header(1).Get("If-None-Match"))
+	assert.Equal(t, []string{"api --method PATCH /repos/owner/repo/pulls/42 -f body=Edited\n<!-- topic-urls:start -->\n- https://github.com/owner/repo/pull/7\n<!-- topic-urls:end -->\n"}, recorder.calls)
+	assert.Equal(t, "📝 Updated the description of #42\n", out.String())
+}
+
+func TestBodyOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          rootOptions
+		expected      bodyOptions
+		expectedError string
+	}{
+		{name: "No update", opts: rootOptions{}},
+		{name: "Dry run", opts: rootOptions{UpdateBody: 42, DryRun: true}, expected: bodyOptions{Number: 42, DryRun: true}},
+		{name: "Dry run without a pull request", opts: rootOptions{DryRun: true}, expectedError: "--dry-run requires --update-body"},
+		{name: "With --web", opts: rootOptions{UpdateBody: 42, Web: true}, expectedError: "--update-body cannot be combined with --web or --watch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body, err := tt.opts.bodyOptions()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// defaultOpenLimit is the number of pull requests --open opens without asking
+const defaultOpenLimit = 5
+
+// browserCommand returns the command opening target with browser, a command
+// line such as "firefox --new-tab", or with the default browser of goos when
+// browser is empty
+func browserCommand(goos, browser, target string) (string, []string) {
+	if fields := strings.Fields(browser); len(fields) > 0 {
+		return fields[0], append(fields[1:], target)
+	}
+
+	switch goos {
+	case "darwin":
+		return "open", []string{target}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", target}
+	default:
+		return "xdg-open", []string{target}
+	}
+}
+
+// browserFromEnv returns the browser set in GH_BROWSER or BROWSER, like gh does
+func browserFromEnv(getenv func(string) string) string {
+	if browser := getenv("GH_BROWSER"); browser != "" {
+		return browser
+	}
+	return getenv("BROWSER")
+}
+
+// openInBrowser is the OpenURL of newApp: it launches the browser configured
+// in the environment, or the platform's default browser
+func (a *App) openInBrowser(target string) error {
+	name, args := browserCommand(runtime.GOOS, browserFromEnv(a.Getenv), target)
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = a.Stdout
+	cmd.Stderr = a.Stderr
+	return cmd.Run()
+}
+
+// pullRequestSearchURL returns the GitHub page listing the pull requests into branch
+func pullRequestSearchURL(repo, branch string) string {
+	return fmt.Sprintf("https://github.com/%s/pulls?q=%s", repo, url.QueryEscape("is:pr base:"+branch))
+}
+
+// openSearchPage opens the pull request search page of branch in the current repository
+func (a *App) openSearchPage(ctx context.Context, branch string) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	target := pullRequestSearchURL(repo, branch)
+	fmt.Fprintf(a.Stderr, "Opening %s in your browser.\n", target)
+	if err := a.OpenURL(target); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// openPullRequests opens every pull request of the report in the browser,
+// asking first when there are more than OpenLimit of them
+func (a *App) openPullRequests(r report) error {
+	var targets []string
+	for _, section := range r.Sections {
+		for _, pr := range section.PullRequests {
+			targets = append(targets, pr.HTMLURL)
+		}
+	}
+
+	if a.OpenLimit > 0 && len(targets) > a.OpenLimit {
+		ok, err := a.confirm(fmt.Sprintf("Open %s in the browser?", countPullRequests(len(targets))))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(a.Stderr, "Not opening pull requests")
+			return nil
+		}
+	}
+
+	for _, target := range targets {
+		if err := a.OpenURL(target); err != nil {
+			return fmt.Errorf("failed to open %s: %w", target, err)
+		}
+	}
+	fmt.Fprintf(a.Stderr, "🌐 Opened %s in the browser\n", countPullRequests(len(targets)))
+	return nil
+}
+
+// countPullRequests renders n with the singular or plural noun, e.g. "1 pull request"
+func countPullRequests(n int) string {
+	if n == 1 {
+		return "1 pull request"
+	}
+	return fmt.Sprintf("%d pull requests", n)
+}
+
+// confirm asks a yes/no question on Stderr and reads the answer from Stdin; anything but yes declines
+func (a *App) confirm(question string) (bool, error) {
+	fmt.Fprintf(a.Stderr, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(a.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(a.Stderr)
+		return false, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
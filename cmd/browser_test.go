@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserCommand(t *testing.T) {
+	t.Parallel()
+
+	url := "https://github.com/owner/repo/pull/1"
+
+	tests := []struct {
+		name         string
+		goos         string
+		browser      string
+		expectedName string
+		expectedArgs []string
+	}{
+		{name: "macOS", goos: "darwin", expectedName: "open", expectedArgs: []string{url}},
+		{name: "Windows", goos: "windows", expectedName: "rundll32", expectedArgs: []string{"url.dll,FileProtocolHandler", url}},
+		{name: "Linux", goos: "linux", expectedName: "xdg-open", expectedArgs: []string{url}},
+		{name: "Configured browser", goos: "darwin", browser: "firefox", expectedName: "firefox", expectedArgs: []string{url}},
+		{name: "Configured browser with arguments", goos: "linux", browser: "firefox --new-tab", expectedName: "firefox", expectedArgs: []string{"--new-tab", url}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, args := browserCommand(tt.goos, tt.browser, url)
+
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestBrowserFromEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{name: "GH_BROWSER wins over BROWSER", env: map[string]string{"GH_BROWSER": "firefox", "BROWSER": "lynx"}, expected: "firefox"},
+		{name: "BROWSER", env: map[string]string{"BROWSER": "lynx"}, expected: "lynx"},
+		{name: "Platform default", env: map[string]string{}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, browserFromEnv(func(key string) string { return tt.env[key] }))
+		})
+	}
+}
+
+func TestOpenInBrowser(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: The App's environment sets the browser to echo
+	app, out := newTestApp(mockExecCommand("", nil))
+	app.Getenv = func(key string) string {
+		if key == "BROWSER" {
+			return "echo"
+		}
+		return ""
+	}
+
+	// Act: Open a pull request
+	err := app.openInBrowser("https://github.com/owner/repo/pull/7")
+
+	// Assert: The browser ran with the App's output
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/owner/repo/pull/7\n", out.String())
+}
+
+func TestPullRequestSearchURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://github.com/owner/repo/pulls?q=is%3Apr+base%3Afeature%2Fpayments", pullRequestSearchURL("owner/repo", "feature/payments"))
+}
+
+func TestOpenPullRequests(t *testing.T) {
+	t.Parallel()
+
+	prs := pickerPullRequests()
+
+	tests := []struct {
+		name           string
+		limit          int
+		stdin          string
+		expectedOpened int
+		expectedOut    string
+	}{
+		{name: "Within the limit", limit: 5, expectedOpened: 3, expectedOut: "🌐 Opened 3 pull requests in the browser\n"},
+		{name: "No limit", limit: 0, expectedOpened: 3, expectedOut: "🌐 Opened 3 pull requests in the browser\n"},
+		{name: "Confirmed", limit: 2, stdin: "y\n", expectedOpened: 3, expectedOut: "Open 3 pull requests in the browser? [y/N] 🌐 Opened 3 pull requests in the browser\n"},
+		{name: "Declined", limit: 2, stdin: "n\n", expectedOut: "Open 3 pull requests in the browser? [y/N] Not opening pull requests\n"},
+		{name: "No answer", limit: 2, expectedOut: "Open 3 pull requests in the browser? [y/N] \nNot opening pull requests\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Record the opened URLs
+			app, out := newTestApp(mockExecCommand("", nil))
+			app.OpenLimit = tt.limit
+			app.Stdin = strings.NewReader(tt.stdin)
+			var opened []string
+			app.OpenURL = func(url string) error {
+				opened = append(opened, url)
+				return nil
+			}
+
+			// Act: Open the pull requests of a report
+			err := app.openPullRequests(report{Sections: []reportSection{{PullRequests: prs}}})
+
+			// Assert: The pull requests are opened unless declined
+			require.NoError(t, err)
+			assert.Len(t, opened, tt.expectedOpened)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
+
+func TestOpenPullRequestsError(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand("", nil))
+	app.OpenURL = func(string) error { return errors.New("xdg-open: not found") }
+
+	err := app.openPullRequests(report{Sections: []reportSection{{PullRequests: pickerPullRequests()}}})
+
+	assert.EqualError(t, err, "failed to open https://github.com/owner/repo/pull/7: xdg-open: not found")
+}
+
+func TestWebAndOpen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		opts           rootOptions
+		expectedOpened []string
+		expectedOut    string
+		expectedError  string
+	}{
+		{
+			name:           "--web opens the search page instead of listing",
+			opts:           rootOptions{NoVerify: true, Web: true},
+			expectedOpened: []string{"https://github.com/owner/repo/pulls?q=is%3Apr+base%3Arelease%2Fnext"},
+			expectedOut:    "Target branch: release/next\nOpening https://github.com/owner/repo/pulls?q=is%3Apr+base%3Arelease%2Fnext in your browser.\n",
+		},
+		{
+			name:           "--open opens the listed pull requests after copying",
+			opts:           rootOptions{NoVerify: true, Open: true, OpenLimit: defaultOpenLimit},
+			expectedOpened: []string{"https://github.com/owner/repo/pull/7"},
+			expectedOut:    "Target branch: release/next\n- https://github.com/owner/repo/pull/7\n✨ Copied to clipboard\n🌐 Opened 1 pull request in the browser\n",
+		},
+		{
+			name:          "--web conflicts with --open",
+			opts:          rootOptions{Web: true, Open: true},
+			expectedError: "--web cannot be combined with --open, --pick, --auto, --from-commits or multi-repository flags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: release/next has one pull request
+			app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+			app.API = newFakeAPI(map[string]string{"base=release%2Fnext": `[{"number":7,"html_url":"https://github.com/owner/repo/pull/7"}]`})
+			var opened []string
+			app.OpenURL = func(url string) error {
+				opened = append(opened, url)
+				return nil
+			}
+
+			// Act: Run the root command on release/next
+			err := app.runTopicUrls(context.Background(), tt.opts, []string{"release/next"})
+
+			// Assert: What was opened and printed
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOpened, opened)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
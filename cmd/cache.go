@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// cacheDirName is the directory created under the user cache directory
+const cacheDirName = "gh-topic-urls"
+
+// errNotCached is returned in offline mode when a response has never been cached
+var errNotCached = errors.New("no cached response available offline")
+
+// cacheEntry is a cached REST API response stored as one JSON file
+type cacheEntry struct {
+	URL       string    `json:"url"`
+	ETag      string    `json:"etag,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Body      string    `json:"body"`
+}
+
+// revalidateKey marks a context whose requests must not be served from the cache without revalidation
+type revalidateKey struct{}
+
+// withRevalidation returns a context whose requests skip the TTL shortcut of the
+// cache, for reads a write is built on
+func withRevalidation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, revalidateKey{}, true)
+}
+
+// mustRevalidate reports whether the requests of ctx skip the TTL shortcut
+func mustRevalidate(ctx context.Context) bool {
+	revalidate, _ := ctx.Value(revalidateKey{}).(bool)
+	return revalidate
+}
+
+// cacheTransport serves REST responses from an on-disk cache. Entries younger
+// than ttl are returned without a request; older entries are revalidated with
+// If-None-Match so unchanged data costs a 304, which does not count against
+// the rate limit. Requests with withRevalidation are always revalidated.
+//
+// In offline mode every cached entry is served regardless of age, nothing is
+// sent to the next transport, and the age of the oldest served entry is kept
+// so the output can be marked as stale.
+type cacheTransport struct {
+	next    apiTransport
+	dir     string
+	ttl     time.Duration
+	offline bool
+	now     func() time.Time
+	// host is the GitHub host the entries belong to
+	host string
+
+	mu           sync.Mutex
+	oldestServed time.Time
+}
+
+func newCacheTransport(next apiTransport, dir string, ttl time.Duration) *cacheTransport {
+	return &cacheTransport{next: next, dir: dir, ttl: ttl, now: time.Now, host: defaultGHHost}
+}
+
+// Get returns a fresh cached response or revalidates it with the next transport
+func (c *cacheTransport) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	key := cacheKey(c.host, path)
+	entry, _ := c.load(key)
+
+	if c.offline {
+		if entry == nil {
+			return nil, fmt.Errorf("%w for %s (run once without --offline to populate the cache)", errNotCached, path)
+		}
+		c.recordServed(entry.FetchedAt)
+		return entry.response(), nil
+	}
+
+	if entry != nil && c.ttl > 0 && c.now().Sub(entry.FetchedAt) < c.ttl && !mustRevalidate(ctx) {
+		return entry.response(), nil
+	}
+
+	if entry != nil && entry.ETag != "" {
+		header = header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := c.next.Get(ctx, path, header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		entry.FetchedAt = c.now()
+		_ = c.store(key, entry)
+		return entry.response(), nil
+	case resp.StatusCode == http.StatusOK:
+		_ = c.store(key, &cacheEntry{
+			URL:       c.host + path,
+			ETag:      resp.Header.Get("ETag"),
+			FetchedAt: c.now(),
+			Body:      string(resp.Body),
+		})
+	}
+
+	return resp, nil
+}
+
+// recordServed remembers the oldest fetch time of the entries served offline
+func (c *cacheTransport) recordServed(fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.oldestServed.IsZero() || fetchedAt.Before(c.oldestServed) {
+		c.oldestServed = fetchedAt
+	}
+}
+
+// staleSince returns the fetch time of the oldest entry served offline, or zero
+func (c *cacheTransport) staleSince() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.oldestServed
+}
+
+func (c *cacheTransport) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *cacheTransport) load(key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *cacheTransport) store(key string, entry *cacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (e *cacheEntry) response() *apiResponse {
+	header := http.Header{}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	return &apiResponse{StatusCode: http.StatusOK, Header: header, Body: []byte(e.Body)}
+}
+
+// cacheKey derives the file name of a cache entry from the API host and request path with query
+func cacheKey(host, path string) string {
+	sum := sha256.Sum256([]byte(host + path))
+	return hex.EncodeToString(sum[:])
+}
+
+// defaultGHHost is the GitHub host gh talks to unless GH_HOST is set
+const defaultGHHost = "github.com"
+
+// ghHost returns the GitHub host gh talks to
+func (a *App) ghHost() string {
+	if host := a.Getenv("GH_HOST"); host != "" {
+		return host
+	}
+	return defaultGHHost
+}
+
+// cacheDir returns $XDG_CACHE_HOME/gh-topic-urls or the platform's user cache directory
+func (a *App) cacheDir() (string, error) {
+	if dir := a.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, cacheDirName), nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, cacheDirName), nil
+}
+
+// configureTransport wraps the App's REST transport for a command: fixture replay
+// or recording when requested through the environment, otherwise the response
+// cache unless disabled
+func (a *App) configureTransport(noCache bool, cacheTTL time.Duration, offline bool) error {
+	if cacheTTL < 0 {
+		return fmt.Errorf("--cache-ttl must not be negative")
+	}
+	if offline && noCache {
+		return fmt.Errorf("--offline cannot be combined with --no-cache")
+	}
+
+	// Fixtures bypass the cache: replay must be deterministic and
+	// recordings must capture real responses
+	if dir := a.Getenv(replayEnv); dir != "" {
+		a.API = &replayTransport{dir: dir}
+		a.replay = true
+		return nil
+	}
+	if dir := a.Getenv(recordEnv); dir != "" {
+		a.API = &recordTransport{next: a.API, dir: dir}
+		return nil
+	}
+
+	if noCache {
+		return nil
+	}
+
+	dir, err := a.cacheDir()
+	if err != nil {
+		if offline {
+			return err
+		}
+		// Caching is an optimization; run uncached rather than fail
+		return nil
+	}
+
+	a.cache = newCacheTransport(a.API, dir, cacheTTL)
+	a.cache.offline = offline
+	a.cache.now = a.Now
+	a.cache.host = a.ghHost()
+	a.API = a.cache
+	return nil
+}
+
+// offline reports whether API responses are served from the cache only
+func (a *App) offline() bool {
+	return a.cache != nil && a.cache.offline
+}
+
+// printOfflineNotice marks the output as stale after a command served from the cache offline
+func printOfflineNotice(cmd *cobra.Command, args []string) {
+	app := appFrom(cmd)
+	if notice := app.offlineNotice(); notice != "" {
+		fmt.Fprintln(app.Stderr, notice)
+	}
+}
+
+// offlineNotice describes how old the results served from the cache are, or returns
+// an empty string when the results were not served offline
+func (a *App) offlineNotice() string {
+	if !a.offline() {
+		return ""
+	}
+
+	fetchedAt := a.cache.staleSince()
+	if fetchedAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("⚠ Offline: showing cached results from %s; they may be stale", fetchedAt.Local().Format("2006-01-02 15:04:05"))
+}
+
+// newCacheCmd builds the cache command and its subcommands
+func newCacheCmd() *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the API response cache",
+	}
+	cacheCmd.AddCommand(newCacheClearCmd(), newCacheStatsCmd())
+	return cacheCmd
+}
+
+func newCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached API responses",
+		Args:  cobra.NoArgs,
+		RunE:  runCacheClear,
+	}
+}
+
+func newCacheStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show cache location, size and age",
+		Args:  cobra.NoArgs,
+		RunE:  runCacheStats,
+	}
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	app := appFrom(cmd)
+	dir, err := app.cacheDir()
+	if err != nil {
+		return err
+	}
+
+	stats, err := readCacheStats(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	fmt.Fprintf(app.Stdout, "✨ Removed %d cached responses from %s\n", stats.Entries, dir)
+	return nil
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	app := appFrom(cmd)
+	dir, err := app.cacheDir()
+	if err != nil {
+		return err
+	}
+
+	stats, err := readCacheStats(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(app.Stdout, "Location: %s\n", dir)
+	fmt.Fprintf(app.Stdout, "Entries:  %d\n", stats.Entries)
+	fmt.Fprintf(app.Stdout, "Size:     %d bytes\n", stats.Bytes)
+	if stats.Entries > 0 {
+		fmt.Fprintf(app.Stdout, "Oldest:   %s\n", stats.Oldest.Local().Format(time.RFC3339))
+		fmt.Fprintf(app.Stdout, "Newest:   %s\n", stats.Newest.Local().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// cacheStats summarizes the entries of a cache directory
+type cacheStats struct {
+	Entries int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// readCacheStats counts cache entries and their total size; a missing directory is an empty cache
+func readCacheStats(dir string) (cacheStats, error) {
+	var stats cacheStats
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return stats, nil
+		}
+		return stats, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+	}
+
+	return stats, nil
+}
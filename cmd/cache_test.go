@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheTransportRevalidatesWithETag(t *testing.T) {
+	// Arrange: First response carries an ETag, second is a 304
+	next := (&fakeAPI{}).on("/repos/o/r/pulls", okResponse(`"v1"`, `[1]`), &apiResponse{StatusCode: http.StatusNotModified, Header: http.Header{}})
+	cache := newCacheTransport(next, t.TempDir(), 0)
+
+	// Act: Request the same path twice
+	first, err1 := cache.Get(context.Background(), "/repos/o/r/pulls", nil)
+	second, err2 := cache.Get(context.Background(), "/repos/o/r/pulls", nil)
+
+	// Assert: The second request is conditional and served from cache
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, `[1]`, string(first.Body))
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, `[1]`, string(second.Body))
+	assert.Len(t, next.paths(), 2)
+	assert.Empty(t, next.header(0).Get("If-None-Match"))
+	assert.Equal(t, `"v1"`, next.header(1).Get("If-None-Match"))
+}
+
+func TestCacheTransportReplacesChangedResponse(t *testing.T) {
+	next := (&fakeAPI{}).on("/path", okResponse(`"v1"`, `[1]`), okResponse(`"v2"`, `[1,2]`), &apiResponse{StatusCode: http.StatusNotModified, Header: http.Header{}})
+	cache := newCacheTransport(next, t.TempDir(), 0)
+
+	_, _ = cache.Get(context.Background(), "/path", nil)
+	changed, err := cache.Get(context.Background(), "/path", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(changed.Body))
+
+	// The new ETag is used for the next revalidation
+	revalidated, err := cache.Get(context.Background(), "/path", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2]`, string(revalidated.Body))
+	assert.Equal(t, `"v2"`, next.header(2).Get("If-None-Match"))
+}
+
+func TestCacheTransportTTL(t *testing.T) {
+	next := (&fakeAPI{}).on("/path", okResponse(`"v1"`, `[1]`), okResponse(`"v2"`, `[2]`), okResponse(`"v3"`, `[3]`))
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	cache := newCacheTransport(next, t.TempDir(), 10*time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, _ = cache.Get(context.Background(), "/path", nil)
+
+	// Within the TTL no request is made
+	now = now.Add(5 * time.Minute)
+	fresh, err := cache.Get(context.Background(), "/path", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1]`, string(fresh.Body))
+	assert.Len(t, next.paths(), 1)
+
+	// After the TTL the entry is revalidated
+	now = now.Add(10 * time.Minute)
+	expired, err := cache.Get(context.Background(), "/path", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[2]`, string(expired.Body))
+	assert.Len(t, next.paths(), 2)
+
+	// Reads a write is built on are revalidated even within the TTL
+	revalidated, err := cache.Get(withRevalidation(context.Background()), "/path", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `[3]`, string(revalidated.Body))
+	assert.Equal(t, `"v2"`, next.header(2).Get("If-None-Match"))
+}
+
+func TestCacheTransportDoesNotStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+	next := &fakeAPI{}
+	cache := newCacheTransport(next, dir, 0)
+
+	resp, err := cache.Get(context.Background(), "/missing", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	stats, err := readCacheStats(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries)
+
+	// Transport errors are passed through
+	cache = newCacheTransport((&fakeAPI{}).on("/path", nil), dir, 0)
+	_, err = cache.Get(context.Background(), "/path", nil)
+	assert.Error(t, err)
+}
+
+func TestCacheKey(t *testing.T) {
+	// Keys differ by host, path and query
+	assert.NotEqual(t, cacheKey("github.com", "/a?page=1"), cacheKey("github.com", "/a?page=2"))
+	assert.NotEqual(t, cacheKey("github.com", "/a"), cacheKey("ghe.example.com", "/a"))
+	assert.Equal(t, cacheKey("github.com", "/a"), cacheKey("github.com", "/a"))
+}
+
+func TestReadCacheStats(t *testing.T) {
+	// A missing directory is an empty cache
+	stats, err := readCacheStats(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stats.Entries)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("12345"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte("123"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "c.tmp"), []byte("ignored"), 0o644))
+
+	stats, err = readCacheStats(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, int64(8), stats.Bytes)
+}
+
+func TestCacheTransportOffline(t *testing.T) {
+	// Arrange: Populate the cache online
+	dir := t.TempDir()
+	fetchedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	online := newCacheTransport((&fakeAPI{}).on("/cached", okResponse(`"v1"`, `[1]`)), dir, 0)
+	online.now = func() time.Time { return fetchedAt }
+	_, err := online.Get(context.Background(), "/cached", nil)
+	assert.NoError(t, err)
+
+	next := &fakeAPI{}
+	cache := newCacheTransport(next, dir, 0)
+	cache.offline = true
+	cache.now = func() time.Time { return fetchedAt.Add(72 * time.Hour) }
+
+	// Act: Request a cached and an uncached path offline
+	cached, cachedErr := cache.Get(context.Background(), "/cached", nil)
+	_, missingErr := cache.Get(context.Background(), "/missing", nil)
+
+	// Assert: Cached data is served regardless of age without touching the network
+	assert.NoError(t, cachedErr)
+	assert.Equal(t, `[1]`, string(cached.Body))
+	assert.ErrorIs(t, missingErr, errNotCached)
+	assert.Empty(t, next.paths())
+	assert.Equal(t, fetchedAt, cache.staleSince().UTC())
+}
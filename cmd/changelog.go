@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// changelogSections lists Keep a Changelog section names in their canonical order
+var changelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// conventionalPrefixPattern matches a conventional commit prefix such as "feat(cli)!: "
+var conventionalPrefixPattern = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?!?:\s*`)
+
+// versionHeadingPattern matches a Keep a Changelog version heading such as "## [1.1.0] - 2025-09-06"
+var versionHeadingPattern = regexp.MustCompile(`^## \[([^\]]+)\]`)
+
+// linkReferencePattern matches link reference definitions placed at the end of a changelog
+var linkReferencePattern = regexp.MustCompile(`^\[[^\]]+\]:\s`)
+
+// changelogOptions holds the flags of the changelog command
+type changelogOptions struct {
+	Version string
+	Range   string
+	File    string
+	Date    string
+	DryRun  bool
+}
+
+// newChangelogCmd builds the changelog command
+func newChangelogCmd() *cobra.Command {
+	var opts changelogOptions
+
+	changelogCmd := &cobra.Command{
+		Use:   "changelog [branch-name]",
+		Short: "Insert a generated release section into CHANGELOG.md",
+		Long: `Render the merged pull requests of a branch or ref range as a Keep a Changelog
+section and insert it into CHANGELOG.md.
+
+Pull requests are grouped by their conventional commit title prefix or labels.
+If a section for the version already exists it is replaced, so the command can
+be run repeatedly.`,
+		Example: `  gh topic-urls changelog --version 1.2.0
+  gh topic-urls changelog release/next --version 1.2.0 --dry-run
+  gh topic-urls changelog --range v1.1.0..HEAD --version 1.2.0`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChangelog(cmd, opts, args)
+		},
+		ValidArgsFunction: branchCompletion,
+	}
+
+	changelogCmd.Flags().StringVar(&opts.Version, "version", "", "Version of the release section (required)")
+	changelogCmd.Flags().StringVar(&opts.Range, "range", "", "Use merged pull requests from a git range instead of a branch")
+	changelogCmd.Flags().StringVar(&opts.File, "file", "CHANGELOG.md", "Changelog file to update")
+	changelogCmd.Flags().StringVar(&opts.Date, "date", "", "Release date (default today)")
+	changelogCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes as a diff without writing the file")
+	_ = changelogCmd.MarkFlagRequired("version")
+
+	return changelogCmd
+}
+
+func runChangelog(cmd *cobra.Command, opts changelogOptions, args []string) error {
+	app := appFrom(cmd)
+	ctx := cmd.Context()
+
+	if opts.Range != "" && len(args) > 0 {
+		return fmt.Errorf("--range cannot be combined with a branch argument")
+	}
+
+	date := opts.Date
+	if date == "" {
+		date = app.Now().Format("2006-01-02")
+	}
+
+	repo, err := app.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	prs, err := app.fetchChangelogPullRequests(ctx, repo, opts.Range, args)
+	if err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		fmt.Fprintln(app.Stdout, "No merged pull requests found")
+		return nil
+	}
+
+	original, err := os.ReadFile(opts.File)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", opts.File, err)
+	}
+
+	section := renderChangelogSection(opts.Version, date, prs)
+	updated := insertChangelogSection(string(original), opts.Version, section)
+
+	if opts.DryRun {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(original)),
+			B:        difflib.SplitLines(updated),
+			FromFile: opts.File,
+			ToFile:   opts.File,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to compute diff: %w", err)
+		}
+		if diff == "" {
+			fmt.Fprintf(app.Stdout, "%s is up to date\n", opts.File)
+			return nil
+		}
+		fmt.Fprint(app.Stdout, diff)
+		return nil
+	}
+
+	if updated == string(original) {
+		fmt.Fprintf(app.Stdout, "%s is up to date\n", opts.File)
+		return nil
+	}
+
+	if err := os.WriteFile(opts.File, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.File, err)
+	}
+
+	fmt.Fprintf(app.Stdout, "✨ Updated %s with %d pull requests for %s\n", opts.File, len(prs), opts.Version)
+	return nil
+}
+
+// fetchChangelogPullRequests returns the merged pull requests for the changelog
+// from rangeSpec, the branch argument, or the current branch
+func (a *App) fetchChangelogPullRequests(ctx context.Context, repo, rangeSpec string, args []string) ([]pullRequest, error) {
+	if rangeSpec != "" {
+		return a.fetchCommitRangePullRequests(ctx, repo, rangeSpec)
+	}
+
+	branchName, err := a.selectBranchForTopicUrls(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.client().ListTopicPRs(ctx, repo, branchName, &topicurls.ListOptions{MergedOnly: true})
+}
+
+// classifyPullRequest returns the Keep a Changelog section for a pull request
+// based on its labels and conventional commit title prefix
+func classifyPullRequest(pr pullRequest) string {
+	for _, l := range pr.Labels {
+		switch strings.ToLower(l.Name) {
+		case "security":
+			return "Security"
+		case "deprecation", "deprecated":
+			return "Deprecated"
+		case "removal", "removed", "breaking-removal":
+			return "Removed"
+		case "bug", "fix":
+			return "Fixed"
+		case "enhancement", "feature":
+			return "Added"
+		}
+	}
+
+	if match := conventionalPrefixPattern.FindStringSubmatch(pr.Title); match != nil {
+		switch strings.ToLower(match[1]) {
+		case "feat":
+			return "Added"
+		case "fix":
+			return "Fixed"
+		case "security":
+			return "Security"
+		case "deprecate":
+			return "Deprecated"
+		case "remove":
+			return "Removed"
+		}
+	}
+
+	return "Changed"
+}
+
+// changelogEntry renders a pull request as a changelog list item
+func changelogEntry(pr pullRequest) string {
+	title := strings.TrimSpace(conventionalPrefixPattern.ReplaceAllString(pr.Title, ""))
+	if title == "" {
+		title = pr.Title
+	}
+	return fmt.Sprintf("- %s ([#%d](%s))", title, pr.Number, pr.HTMLURL)
+}
+
+// renderChangelogSection renders a version section with one subsection per change type
+func renderChangelogSection(version, date string, prs []pullRequest) string {
+	grouped := make(map[string][]string)
+	for _, pr := range prs {
+		section := classifyPullRequest(pr)
+		grouped[section] = append(grouped[section], changelogEntry(pr))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s] - %s\n", version, date)
+	for _, section := range changelogSections {
+		entries := grouped[section]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n", section)
+		for _, entry := range entries {
+			sb.WriteString(entry + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// insertChangelogSection inserts section into a changelog, replacing an existing
+// section for the same version. New sections go after [Unreleased] and before
+// the most recent release; a missing changelog gets the standard header.
+func insertChangelogSection(content, version, section string) string {
+	if strings.TrimSpace(content) == "" {
+		content = "# Changelog\n\n## [Unreleased]\n"
+	}
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	sectionLines := strings.Split(strings.TrimRight(section, "\n"), "\n")
+
+	start, end := -1, len(lines)
+	insertAt := -1
+	for i, line := range lines {
+		if start >= 0 {
+			if versionHeadingPattern.MatchString(line) || linkReferencePattern.MatchString(line) {
+				end = i
+				break
+			}
+			continue
+		}
+
+		match := versionHeadingPattern.FindStringSubmatch(line)
+		if match == nil {
+			if insertAt < 0 && linkReferencePattern.MatchString(line) {
+				insertAt = i
+			}
+			continue
+		}
+		if match[1] == version {
+			start = i
+			continue
+		}
+		if insertAt < 0 && !strings.EqualFold(match[1], "Unreleased") {
+			insertAt = i
+		}
+	}
+
+	if start < 0 {
+		if insertAt < 0 {
+			insertAt = len(lines)
+		}
+		start, end = insertAt, insertAt
+	}
+
+	result := make([]string, 0, len(lines)+len(sectionLines)+2)
+	result = append(result, trimTrailingBlankLines(lines[:start])...)
+	result = append(result, "")
+	result = append(result, sectionLines...)
+	if end < len(lines) {
+		result = append(result, "")
+		result = append(result, trimLeadingBlankLines(lines[end:])...)
+	}
+
+	return strings.Join(result, "\n") + "\n"
+}
+
+func trimTrailingBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func trimLeadingBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return lines
+}
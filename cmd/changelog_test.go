@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPullRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		pr       pullRequest
+		expected string
+	}{
+		{name: "Feature prefix", pr: pullRequest{Title: "feat: add diff command"}, expected: "Added"},
+		{name: "Scoped fix prefix", pr: pullRequest{Title: "fix(cli): handle empty branch"}, expected: "Fixed"},
+		{name: "Breaking feature prefix", pr: pullRequest{Title: "feat!: drop jq"}, expected: "Added"},
+		{name: "Other prefix", pr: pullRequest{Title: "refactor: extract formatter"}, expected: "Changed"},
+		{name: "No prefix", pr: pullRequest{Title: "Update README"}, expected: "Changed"},
+		{
+			name:     "Label takes precedence over prefix",
+			pr:       pullRequest{Title: "fix: escape output", Labels: []label{{Name: "Security"}}},
+			expected: "Security",
+		},
+		{
+			name:     "Deprecation label",
+			pr:       pullRequest{Title: "chore: warn on old flag", Labels: []label{{Name: "deprecation"}}},
+			expected: "Deprecated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyPullRequest(tt.pr))
+		})
+	}
+}
+
+func TestRenderChangelogSection(t *testing.T) {
+	mergedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	prs := []pullRequest{
+		{Number: 3, Title: "fix: handle detached HEAD", HTMLURL: "https://github.com/owner/repo/pull/3", MergedAt: &mergedAt},
+		{Number: 1, Title: "feat(diff): add diff command", HTMLURL: "https://github.com/owner/repo/pull/1", MergedAt: &mergedAt},
+		{Number: 2, Title: "Update docs", HTMLURL: "https://github.com/owner/repo/pull/2", MergedAt: &mergedAt},
+	}
+
+	result := renderChangelogSection("1.2.0", "2025-09-10", prs)
+
+	assert.Equal(t, `## [1.2.0] - 2025-09-10
+
+### Added
+- add diff command ([#1](https://github.com/owner/repo/pull/1))
+
+### Changed
+- Update docs ([#2](https://github.com/owner/repo/pull/2))
+
+### Fixed
+- handle detached HEAD ([#3](https://github.com/owner/repo/pull/3))
+`, result)
+}
+
+func TestInsertChangelogSection(t *testing.T) {
+	section := "## [1.2.0] - 2025-09-10\n\n### Added\n- New thing\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name: "Insert between Unreleased and latest release",
+			content: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2025-09-06
+
+### Added
+- Old thing
+`,
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-09-10
+
+### Added
+- New thing
+
+## [1.1.0] - 2025-09-06
+
+### Added
+- Old thing
+`,
+		},
+		{
+			name: "Replace existing section for the same version",
+			content: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-09-01
+
+### Fixed
+- Stale entry
+
+## [1.1.0] - 2025-09-06
+`,
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-09-10
+
+### Added
+- New thing
+
+## [1.1.0] - 2025-09-06
+`,
+		},
+		{
+			name: "Append when only Unreleased exists",
+			content: `# Changelog
+
+## [Unreleased]
+- Pending change
+`,
+			expected: `# Changelog
+
+## [Unreleased]
+- Pending change
+
+## [1.2.0] - 2025-09-10
+
+### Added
+- New thing
+`,
+		},
+		{
+			name: "Keep link references at the end",
+			content: `# Changelog
+
+## [Unreleased]
+
+[Unreleased]: https://github.com/owner/repo/compare/v1.1.0...HEAD
+`,
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-09-10
+
+### Added
+- New thing
+
+[Unreleased]: https://github.com/owner/repo/compare/v1.1.0...HEAD
+`,
+		},
+		{
+			name:    "Create missing changelog",
+			content: "",
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2025-09-10
+
+### Added
+- New thing
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Insert section
+			result := insertChangelogSection(tt.content, "1.2.0", section)
+
+			// Assert: Verify content and idempotency
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, result, insertChangelogSection(result, "1.2.0", section))
+		})
+	}
+}
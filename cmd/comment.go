@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// commentMarker is a hidden line identifying the comments posted by gh-topic-urls,
+// so --update-comment can find the previous one
+const commentMarker = "<!-- gh-topic-urls -->"
+
+// commentPageSize is the number of comments listed per request when looking for the marker
+const commentPageSize = 100
+
+// commentOptions are set from --comment-on, --comment-on-issue and --update-comment
+type commentOptions struct {
+	// Number is the pull request or issue of the current repository to comment on; 0 disables commenting
+	Number int
+	// Issue is set when Number is an issue rather than a pull request
+	Issue bool
+	// Update edits the previous comment carrying commentMarker instead of adding a new one
+	Update bool
+}
+
+// issueComment is a pull request or issue comment returned by the REST API
+type issueComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// commentBody returns the comment posted for output rendered in format: the
+// marker followed by the output
+func commentBody(format, output string) string {
+	return commentMarker + "\n" + markdownBlock(format, output)
+}
+
+// markdownBlock returns output rendered in format as Markdown, fencing JSON in a code block
+func markdownBlock(format, output string) string {
+	if format == "json" {
+		return "```json\n" + strings.TrimSuffix(output, "\n") + "\n```\n"
+	}
+	return output
+}
+
+// postComment posts the rendered output on the pull request or issue of
+// a.Comment, or edits the previous marked comment with --update-comment
+func (a *App) postComment(ctx context.Context, output string) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	number := a.Comment.Number
+	if !a.Comment.Issue {
+		// Issue comment endpoints accept pull request numbers too; make sure this is one
+		if _, err := a.ghAPIFresh(ctx, fmt.Sprintf("/repos/%s/pulls/%d", repo, number)); err != nil {
+			return fmt.Errorf("pull request #%d not found in %s: %w", number, repo, err)
+		}
+	}
+
+	body := commentBody(a.Format, output)
+
+	if a.Comment.Update {
+		previous, err := a.findMarkedComment(ctx, repo, number)
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			updated, err := a.writeComment(ctx, "PATCH", fmt.Sprintf("/repos/%s/issues/comments/%d", repo, previous.ID), body)
+			if err != nil {
+				return fmt.Errorf("failed to update comment: %w", err)
+			}
+			fmt.Fprintf(a.Stderr, "💬 Updated comment %s\n", updated.HTMLURL)
+			return nil
+		}
+	}
+
+	created, err := a.writeComment(ctx, "POST", fmt.Sprintf("/repos/%s/issues/%d/comments", repo, number), body)
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	fmt.Fprintf(a.Stderr, "💬 Posted comment %s\n", created.HTMLURL)
+	return nil
+}
+
+// findMarkedComment returns the most recent comment of a pull request or issue
+// that carries commentMarker and was written by the authenticated user, or nil
+// when there is none. Marked comments of others, such as CI, cannot be edited.
+func (a *App) findMarkedComment(ctx context.Context, repo string, number int) (*issueComment, error) {
+	login, err := a.currentLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *issueComment
+	for page := 1; ; page++ {
+		data, err := a.ghAPIFresh(ctx, fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=%d&page=%d", repo, number, commentPageSize, page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+
+		var comments []issueComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			return nil, fmt.Errorf("failed to decode comments: %w", err)
+		}
+		for i := range comments {
+			if comments[i].User.Login == login && strings.Contains(comments[i].Body, commentMarker) {
+				found = &comments[i]
+			}
+		}
+
+		if len(comments) < commentPageSize {
+			return found, nil
+		}
+	}
+}
+
+// currentLogin returns the login of the user gh is authenticated as
+func (a *App) currentLogin(ctx context.Context) (string, error) {
+	data, err := a.ghAPI(ctx, "/user")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the authenticated user: %w", err)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(data, &user); err != nil {
+		return "", fmt.Errorf("failed to decode the authenticated user: %w", err)
+	}
+	return user.Login, nil
+}
+
+// writeComment sends a comment body with method to path and decodes the resulting comment
+func (a *App) writeComment(ctx context.Context, method, path, body string) (*issueComment, error) {
+	output, err := a.ghAPIWrite(ctx, method, path, map[string]string{"body": body})
+	if err != nil {
+		return nil, err
+	}
+
+	var comment issueComment
+	if err := json.Unmarshal(output, &comment); err != nil {
+		return nil, fmt.Errorf("failed to decode comment: %w", err)
+	}
+	return &comment, nil
+}
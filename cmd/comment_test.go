@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ghWriteRecorder answers gh commands with output and git commands from
+// defaultGitOutputs, recording the gh arguments
+type ghWriteRecorder struct {
+	output string
+	calls  []string
+}
+
+func (r *ghWriteRecorder) run(ctx context.Context, name string, args ...string) *exec.Cmd {
+	if name != "gh" {
+		return mockGitCommands(defaultGitOutputs)(ctx, name, args...)
+	}
+	r.calls = append(r.calls, strings.Join(args, " "))
+	return exec.Command("echo", "-n", r.output)
+}
+
+func TestCommentBody(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "<!-- gh-topic-urls -->\n- https://github.com/owner/repo/pull/7\n", commentBody("markdown", "- https://github.com/owner/repo/pull/7\n"))
+	assert.Equal(t, "<!-- gh-topic-urls -->\n```json\n{\"sections\":[]}\n```\n", commentBody("json", "{\"sections\":[]}\n"))
+}
+
+func TestCommentOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          rootOptions
+		expected      commentOptions
+		expectedError string
+	}{
+		{name: "No comment", opts: rootOptions{}},
+		{name: "Pull request", opts: rootOptions{CommentOn: 42, UpdateComment: true}, expected: commentOptions{Number: 42, Update: true}},
+		{name: "Issue", opts: rootOptions{CommentOnIssue: 9}, expected: commentOptions{Number: 9, Issue: true}},
+		{name: "Both targets", opts: rootOptions{CommentOn: 42, CommentOnIssue: 9}, expectedError: "--comment-on cannot be combined with --comment-on-issue"},
+		{name: "Update without a target", opts: rootOptions{UpdateComment: true}, expectedError: "--update-comment requires --comment-on or --comment-on-issue"},
+		{name: "Negative number", opts: rootOptions{CommentOn: -1}, expectedError: "--comment-on and --comment-on-issue take a positive number"},
+		{name: "With --watch", opts: rootOptions{CommentOn: 42, Watch: true}, expectedError: "--comment-on and --comment-on-issue cannot be combined with --web or --watch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			comment, err := tt.opts.commentOptions()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, comment)
+		})
+	}
+}
+
+func TestPostComment(t *testing.T) {
+	t.Parallel()
+
+	const (
+		pullPath     = "/repos/owner/repo/pulls/42"
+		userPath     = "/user"
+		commentsPath = "/repos/owner/repo/issues/42/comments?per_page=100&page=1"
+		body         = "<!-- gh-topic-urls -->\n- https://github.com/owner/repo/pull/7\n"
+	)
+
+	tests := []struct {
+		name          string
+		comment       commentOptions
+		bodies        map[string]string
+		expectedCall  string
+		expectedOut   string
+		expectedError string
+	}{
+		{
+			name:         "Posts a new comment on the pull request",
+			comment:      commentOptions{Number: 42},
+			bodies:       map[string]string{pullPath: `{"number":42}`},
+			expectedCall: "api --method POST /repos/owner/repo/issues/42/comments -f body=" + body,
+			expectedOut:  "💬 Posted comment https://github.com/owner/repo/pull/42#issuecomment-1\n",
+		},
+		{
+			name:    "Updates the latest marked comment",
+			comment: commentOptions{Number: 42, Update: true},
+			bodies: map[string]string{
+				pullPath:     `{"number":42}`,
+				userPath:     `{"login":"octocat"}`,
+				commentsPath: `[{"id":5,"body":"<!-- gh-topic-urls -->\nold","user":{"login":"octocat"}},{"id":6,"body":"LGTM","user":{"login":"octocat"}},{"id":8,"body":"<!-- gh-topic-urls -->\nprevious run","user":{"login":"octocat"}}]`,
+			},
+			expectedCall: "api --method PATCH /repos/owner/repo/issues/comments/8 -f body=" + body,
+			expectedOut:  "💬 Updated comment https://github.com/owner/repo/pull/42#issuecomment-1\n",
+		},
+		{
+			name:    "Posts when there is no marked comment to update",
+			comment: commentOptions{Number: 42, Update: true},
+			bodies: map[string]string{
+				pullPath:     `{"number":42}`,
+				userPath:     `{"login":"octocat"}`,
+				commentsPath: `[{"id":6,"body":"LGTM","user":{"login":"octocat"}}]`,
+			},
+			expectedCall: "api --method POST /repos/owner/repo/issues/42/comments -f body=" + body,
+			expectedOut:  "💬 Posted comment https://github.com/owner/repo/pull/42#issuecomment-1\n",
+		},
+		{
+			name:    "Marked comments of other users are not edited",
+			comment: commentOptions{Number: 42, Update: true},
+			bodies: map[string]string{
+				pullPath:     `{"number":42}`,
+				userPath:     `{"login":"octocat"}`,
+				commentsPath: `[{"id":5,"body":"<!-- gh-topic-urls -->\nfrom CI","user":{"login":"github-actions[bot]"}}]`,
+			},
+			expectedCall: "api --method POST /repos/owner/repo/issues/42/comments -f body=" + body,
+			expectedOut:  "💬 Posted comment https://github.com/owner/repo/pull/42#issuecomment-1\n",
+		},
+		{
+			name:         "Issues are not checked to be pull requests",
+			comment:      commentOptions{Number: 42, Issue: true},
+			expectedCall: "api --method POST /repos/owner/repo/issues/42/comments -f body=" + body,
+			expectedOut:  "💬 Posted comment https://github.com/owner/repo/pull/42#issuecomment-1\n",
+		},
+		{
+			name:          "Unknown pull request",
+			comment:       commentOptions{Number: 42},
+			expectedError: "pull request #42 not found in owner/repo: gh api error: HTTP 404: Not Found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: gh answers writes with the resulting comment
+			recorder := &ghWriteRecorder{output: `{"id":1,"html_url":"https://github.com/owner/repo/pull/42#issuecomment-1"}`}
+			app, out := newTestApp(recorder.run)
+			app.API = newFakeAPI(tt.bodies)
+			app.Comment = tt.comment
+
+			// Act: Post the rendered output
+			err := app.postComment(context.Background(), "- https://github.com/owner/repo/pull/7\n")
+
+			// Assert: One write with the marked body
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Empty(t, recorder.calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expectedCall}, recorder.calls)
+			assert.Equal(t, tt.expectedOut, out.String())
+		})
+	}
+}
+
+func TestPostCommentRevalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Fresh cache entries predate the comment posted by the previous run
+	const (
+		pullPath     = "/repos/owner/repo/pulls/42"
+		commentsPath = "/repos/owner/repo/issues/42/comments?per_page=100&page=1"
+	)
+	recorder := &ghWriteRecorder{output: `{"id":9,"html_url":"https://github.com/owner/repo/pull/42#issuecomment-9"}`}
+	app, out := newTestApp(recorder.run)
+	next := (&fakeAPI{}).
+		on(pullPath, okResponse(`"p1"`, `{"number":42}`), &apiResponse{StatusCode: http.StatusNotModified, Header: http.Header{}}).
+		on(commentsPath, okResponse(`"c1"`, `[]`), okResponse(`"c2"`, `[{"id":9,"body":"<!-- gh-topic-urls -->\nprevious run","user":{"login":"octocat"}}]`)).
+		on("/user", okResponse("", `{"login":"octocat"}`))
+	cache := newCacheTransport(next, t.TempDir(), time.Hour)
+	cache.now = app.Now
+	for _, path := range []string{pullPath, commentsPath} {
+		_, err := cache.Get(context.Background(), path, nil)
+		require.NoError(t, err)
+	}
+	app.API = cache
+	app.Comment = commentOptions{Number: 42, Update: true}
+
+	// Act: Update the comment within the TTL
+	err := app.postComment(context.Background(), "- https://github.com/owner/repo/pull/7\n")
+
+	// Assert: The comment list is revalidated and the previous comment is edited, not duplicated
+	require.NoError(t, err)
+	assert.Len(t, next.paths(), 5)
+	assert.Equal(t, []string{"api --method PATCH /repos/owner/repo/issues/comments/9 -f body=<!-- gh-topic-urls -->\n- https://github.com/owner/repo/pull/7\n"}, recorder.calls)
+	assert.Equal(t, "💬 Updated comment https://github.com/owner/repo/pull/42#issuecomment-9\n", out.String())
+}
+
+func TestFindMarkedCommentPages(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A full first page without the marker, the marker on the second page
+	first := "[" + strings.TrimSuffix(strings.Repeat(`{"id":1,"body":"LGTM"},`, commentPageSize), ",") + "]"
+	app, _ := newTestApp(mockExecCommand("", nil))
+	api := newFakeAPI(map[string]string{
+		"/repos/owner/repo/issues/42/comments?per_page=100&page=1": first,
+		"/repos/owner/repo/issues/42/comments?per_page=100&page=2": `[{"id":2,"body":"<!-- gh-topic-urls -->\n- url","user":{"login":"octocat"}}]`,
+		"/user": `{"login":"octocat"}`,
+	})
+	app.API = api
+
+	// Act: Look for the marked comment
+	comment, err := app.findMarkedComment(context.Background(), "owner/repo", 42)
+
+	// Assert: Both pages were read
+	require.NoError(t, err)
+	require.NotNil(t, comment)
+	assert.Equal(t, int64(2), comment.ID)
+	assert.Len(t, api.paths(), 3)
+}
+
+func TestOutputReportPostsComment(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: --comment-on-issue 9
+	recorder := &ghWriteRecorder{output: `{"id":1,"html_url":"https://github.com/owner/repo/issues/9#issuecomment-1"}`}
+	app, out := newTestApp(recorder.run)
+	app.Comment = commentOptions{Number: 9, Issue: true}
+
+	// Act: Output a pull request list
+	err := app.outputPullRequests(context.Background(), "owner/repo", pickerPullRequests()[:1])
+
+	// Assert: The comment follows the copy
+	require.NoError(t, err)
+	assert.Equal(t, "- https://github.com/owner/repo/pull/7\n✨ Copied to clipboard\n💬 Posted comment https://github.com/owner/repo/issues/9#issuecomment-1\n", out.String())
+	assert.Len(t, recorder.calls, 1)
+}
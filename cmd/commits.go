@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+)
+
+// commitLookupConcurrency limits parallel commits/{sha}/pulls requests
+const commitLookupConcurrency = 8
+
+// normalizeCommitRange turns a single ref into a "ref..HEAD" range
+func normalizeCommitRange(rangeSpec string) string {
+	rangeSpec = strings.TrimSpace(rangeSpec)
+	if rangeSpec == "" || strings.Contains(rangeSpec, "..") {
+		return rangeSpec
+	}
+	return rangeSpec + "..HEAD"
+}
+
+// getCommitsInRange lists commit SHAs in the given range, oldest first
+func (a *App) getCommitsInRange(ctx context.Context, rangeSpec string) ([]string, error) {
+	rangeSpec = normalizeCommitRange(rangeSpec)
+	if rangeSpec == "" {
+		return nil, fmt.Errorf("commit range must not be empty")
+	}
+
+	repo, err := a.openGitRepo("")
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.Commits(ctx, rangeSpec)
+}
+
+// fetchCommitPullRequests returns the pull requests associated with a commit
+func (a *App) fetchCommitPullRequests(ctx context.Context, repo, sha string) ([]pullRequest, error) {
+	output, err := a.ghAPI(ctx, fmt.Sprintf("/repos/%s/commits/%s/pulls", repo, sha))
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []pullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to decode pull requests for commit %s: %w", sha, err)
+	}
+
+	return prs, nil
+}
+
+// isUnknownCommit reports whether a commits/{sha}/pulls error means GitHub does
+// not know the commit, e.g. 422 "No commit found for SHA" for local work that
+// has not been pushed
+func isUnknownCommit(err error) bool {
+	var apiErr *topicurls.APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusUnprocessableEntity)
+}
+
+// resolvePullRequestsFromCommits maps commits to the merged pull requests that
+// introduced them. Pull requests are deduplicated and ordered by the first
+// commit that references them. Commits unknown to GitHub are skipped with a
+// warning; any other failure stops the remaining lookups.
+func (a *App) resolvePullRequestsFromCommits(ctx context.Context, repo string, commits []string) ([]pullRequest, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]pullRequest, len(commits))
+	unknown := make([]error, len(commits))
+
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		failure  error
+	)
+	sem := make(chan struct{}, commitLookupConcurrency)
+	for i, sha := range commits {
+		wg.Add(1)
+		go func(i int, sha string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			prs, err := a.fetchCommitPullRequests(ctx, repo, sha)
+			switch {
+			case isUnknownCommit(err):
+				unknown[i] = err
+			case err != nil:
+				failOnce.Do(func() {
+					failure = err
+					cancel()
+				})
+			default:
+				results[i] = prs
+			}
+		}(i, sha)
+	}
+	wg.Wait()
+
+	if failure != nil {
+		return nil, failure
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var prs []pullRequest
+	for i, sha := range commits {
+		if unknown[i] != nil {
+			fmt.Fprintf(a.Stderr, "⚠ skipping commit %s: %v\n", shortSHA(sha), unknown[i])
+			continue
+		}
+		for _, pr := range results[i] {
+			// Open or closed-unmerged pull requests did not bring the commit in
+			if pr.MergedAt == nil || seen[pr.Number] {
+				continue
+			}
+			seen[pr.Number] = true
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs, nil
+}
+
+// shortSHA abbreviates a commit SHA for messages
+func shortSHA(sha string) string {
+	return sha[:min(len(sha), 7)]
+}
+
+// fetchCommitRangePullRequests returns the merged pull requests behind the commits in a range
+func (a *App) fetchCommitRangePullRequests(ctx context.Context, repo, rangeSpec string) ([]pullRequest, error) {
+	commits, err := a.getCommitsInRange(ctx, rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.resolvePullRequestsFromCommits(ctx, repo, commits)
+}
+
+// getCommitRangeUrls lists the pull requests that contributed commits to the given range
+func (a *App) getCommitRangeUrls(ctx context.Context, rangeSpec string) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	prs, err := a.fetchCommitRangePullRequests(ctx, repo, rangeSpec)
+	if err != nil {
+		return err
+	}
+
+	if len(prs) == 0 {
+		fmt.Fprintf(a.Stderr, "No pull requests found for commits in '%s'\n", normalizeCommitRange(rangeSpec))
+		return nil
+	}
+
+	return a.outputPullRequests(ctx, repo, prs)
+}
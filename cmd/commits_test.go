@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCommitRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Two dot range", input: "main..release/next", expected: "main..release/next"},
+		{name: "Three dot range", input: "v1.0.0...v1.1.0", expected: "v1.0.0...v1.1.0"},
+		{name: "Single ref", input: "v1.0.0", expected: "v1.0.0..HEAD"},
+		{name: "Surrounding whitespace", input: "  main  ", expected: "main..HEAD"},
+		{name: "Empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, normalizeCommitRange(tt.input))
+		})
+	}
+}
+
+func TestGetCommitsInRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		rangeSpec   string
+		mockOutput  string
+		mockError   error
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:       "Multiple commits",
+			rangeSpec:  "main..feature",
+			mockOutput: "aaa111\nbbb222\nccc333\n",
+			expected:   []string{"aaa111", "bbb222", "ccc333"},
+		},
+		{
+			name:       "No commits",
+			rangeSpec:  "main..main",
+			mockOutput: "",
+			expected:   nil,
+		},
+		{
+			name:        "Empty range",
+			rangeSpec:   "",
+			expectError: true,
+		},
+		{
+			name:        "Git command error",
+			rangeSpec:   "unknown..HEAD",
+			mockError:   fmt.Errorf("bad revision"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Setup mock command execution
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
+
+			// Act: List commits
+			result, err := app.getCommitsInRange(context.Background(), tt.rangeSpec)
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestResolvePullRequestsFromCommits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mockOutput  string
+		mockError   error
+		commits     []string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name: "Merged pull requests are deduplicated across commits",
+			mockOutput: mockAPIOutput(200, `[{"number":7,"merged_at":"2025-09-01T00:00:00Z"},
+				{"number":3,"merged_at":"2025-08-01T00:00:00Z"}]`),
+			commits:  []string{"aaa", "bbb"},
+			expected: []int{7, 3},
+		},
+		{
+			name:       "Unmerged pull requests are ignored",
+			mockOutput: mockAPIOutput(200, `[{"number":8,"merged_at":null}]`),
+			commits:    []string{"aaa"},
+			expected:   nil,
+		},
+		{
+			name:     "No commits",
+			commits:  nil,
+			expected: nil,
+		},
+		{
+			name:        "API error",
+			mockError:   fmt.Errorf("gh command failed"),
+			commits:     []string{"aaa"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Setup mock command execution
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
+
+			// Act: Resolve pull requests
+			prs, err := app.resolvePullRequestsFromCommits(context.Background(), "owner/repo", tt.commits)
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var numbers []int
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+}
+
+func TestResolvePullRequestsFromLargeRange(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A release range of 500 squash-merged pull requests
+	const size = 500
+	bodies := make(map[string]string, size)
+	commits := make([]string, size)
+	for i := range commits {
+		commits[i] = fmt.Sprintf("%040x", i)
+		bodies[fmt.Sprintf("/repos/owner/repo/commits/%s/pulls", commits[i])] = fmt.Sprintf(`[{"number":%d,"merged_at":"2025-09-01T00:00:00Z"}]`, i+1)
+	}
+	app, _ := newTestApp(mockExecCommand("", nil))
+	api := newFakeAPI(bodies)
+	app.API = api
+
+	// Act: Resolve the pull requests of the range
+	prs, err := app.resolvePullRequestsFromCommits(context.Background(), "owner/repo", commits)
+
+	// Assert: Only each lookup is bounded, so the whole range resolves
+	require.NoError(t, err)
+	assert.Len(t, prs, size)
+	assert.Equal(t, size, prs[size-1].Number)
+	assert.Len(t, api.paths(), size)
+}
+
+func TestResolvePullRequestsSkipsUnknownCommits(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: The second commit of the range has not been pushed
+	app, out := newTestApp(mockExecCommand("", nil))
+	app.API = newFakeAPI(map[string]string{
+		"/repos/owner/repo/commits/aaa111/pulls": `[{"number":5,"merged_at":"2025-09-01T00:00:00Z"}]`,
+		"/repos/owner/repo/commits/ccc333/pulls": `[{"number":7,"merged_at":"2025-09-02T00:00:00Z"}]`,
+	}).on("/repos/owner/repo/commits/bbb222/pulls", &apiResponse{
+		StatusCode: http.StatusUnprocessableEntity,
+		Header:     http.Header{},
+		Body:       []byte(`{"message":"No commit found for SHA: bbb222"}`),
+	})
+
+	// Act: Resolve the pull requests of the range
+	prs, err := app.resolvePullRequestsFromCommits(context.Background(), "owner/repo", []string{"aaa111", "bbb222", "ccc333"})
+
+	// Assert: The pull requests of the known commits are listed, with a warning for the other
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 7}, []int{prs[0].Number, prs[1].Number})
+	assert.Equal(t, "⚠ skipping commit bbb222: gh api error: HTTP 422: No commit found for SHA: bbb222\n", out.String())
+}
+
+func TestResolvePullRequestsStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Every lookup fails with a server error
+	commits := make([]string, 100)
+	for i := range commits {
+		commits[i] = fmt.Sprintf("%040x", i)
+	}
+	app, _ := newTestApp(mockExecCommand("", nil))
+	api := (&fakeAPI{}).on("/pulls", &apiResponse{
+		StatusCode: http.StatusInternalServerError,
+		Header:     http.Header{},
+		Body:       []byte(`{"message":"Server Error"}`),
+	})
+	app.API = api
+
+	// Act: Resolve the pull requests of the range
+	_, err := app.resolvePullRequestsFromCommits(context.Background(), "owner/repo", commits)
+
+	// Assert: The first failure is returned and the remaining lookups are not sent
+	assert.EqualError(t, err, "gh api error: HTTP 500: Server Error")
+	assert.LessOrEqual(t, len(api.paths()), commitLookupConcurrency)
+}
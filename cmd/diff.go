@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/spf13/cobra"
+)
+
+// newDiffCmd builds the diff command
+func newDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <from>..<to>",
+		Short: "List merged pull requests between two tags or refs",
+		Long: `List the merged pull requests whose commits are in <to> but not in <from>.
+
+Commits are computed with the local git repository, so both refs must be
+available locally (fetch tags first if needed). When <to> is omitted, HEAD
+is used.`,
+		Example: `  gh topic-urls diff v1.2.0..v1.3.0
+  gh topic-urls diff v1.2.0.. --format json`,
+		Args:              cobra.ExactArgs(1),
+		RunE:              runDiff,
+		ValidArgsFunction: cobra.NoFileCompletions,
+	}
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	app := appFrom(cmd)
+	ctx := cmd.Context()
+
+	if err := validateFormat(app.Format); err != nil {
+		return err
+	}
+
+	from, to, err := topicurls.SplitRange(args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range []string{from, to} {
+		exists, err := app.refExists(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to check ref existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("ref '%s' does not exist", ref)
+		}
+	}
+
+	rangeSpec := fmt.Sprintf("%s..%s", from, to)
+	fmt.Fprintf(app.Stderr, "Comparing: %s\n", rangeSpec)
+
+	if err := app.getCommitRangeUrls(ctx, rangeSpec); err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefExists(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mockError error
+		expected  bool
+	}{
+		{name: "Ref exists", expected: true},
+		{name: "Ref does not exist", mockError: fmt.Errorf("unknown revision"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Setup mock command execution
+			app, _ := newTestApp(mockExecCommand("", tt.mockError))
+
+			// Act: Check ref
+			result, err := app.refExists(context.Background(), "v1.2.0")
+
+			// Assert: Verify result
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
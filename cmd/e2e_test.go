@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata/e2e")
+
+// e2eFixtureDir holds the API fixtures replayed by the end-to-end tests
+const e2eFixtureDir = "testdata/e2e/fixtures"
+
+// mockGitCommands answers git commands from a map keyed by their arguments;
+// unknown commands fail like a missing ref would
+func mockGitCommands(outputs map[string]string) func(context.Context, string, ...string) *exec.Cmd {
+	return func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		output, ok := outputs[strings.Join(args, " ")]
+		if name != "git" || !ok {
+			return exec.Command("false")
+		}
+		return exec.Command("echo", "-n", output)
+	}
+}
+
+// defaultGitOutputs describes a checkout of owner/repo on release/next
+var defaultGitOutputs = map[string]string{
+	"remote get-url origin":                                     "git@github.com:owner/repo.git",
+	"branch --show-current":                                     "release/next",
+	"show-ref --verify --quiet refs/heads/release/next":         "",
+	"show-ref --verify --quiet refs/heads/empty-branch":         "",
+	"show-ref --verify --quiet refs/remotes/origin/missing-fix": "",
+	"rev-parse --verify --quiet v1.0.0^{commit}":                "",
+	"rev-parse --verify --quiet v1.1.0^{commit}":                "",
+	"log --format=%H --reverse v1.0.0..HEAD --":                 "aaa111\nbbb222\nccc333\n",
+	"log --format=%H --reverse v1.0.0..v1.1.0 --":               "aaa111\nbbb222\n",
+}
+
+// runE2E executes the root command against the replayed fixtures and returns
+// stdout, stderr and the clipboard content
+func runE2E(t *testing.T, args []string) (string, string, string, error) {
+	t.Helper()
+
+	var clip string
+	var stderr bytes.Buffer
+	app, out := newTestApp(mockGitCommands(defaultGitOutputs))
+	app.Stderr = &stderr
+	app.Getenv = func(key string) string {
+		if key == replayEnv {
+			return e2eFixtureDir
+		}
+		return ""
+	}
+	app.Clipboard = func(text string) error {
+		clip = text
+		return nil
+	}
+
+	rootCmd := newRootCmd()
+	rootCmd.SetArgs(args)
+	err := rootCmd.ExecuteContext(withApp(context.Background(), app))
+
+	return out.String(), stderr.String(), clip, err
+}
+
+func TestEndToEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "current_branch", args: []string{}},
+		{name: "branch_json", args: []string{"release/next", "--format", "json"}},
+		{name: "with_issues", args: []string{"release/next", "--with-issues"}},
+		{name: "plain", args: []string{"release/next", "-f", "plain"}},
+		{name: "empty_branch", args: []string{"empty-branch"}},
+		{name: "from_commits", args: []string{"--from-commits", "v1.0.0"}},
+		{name: "diff", args: []string{"diff", "v1.0.0..v1.1.0"}},
+		{name: "repos", args: []string{"--repos", "org/api,org/web,org/worker", "feature/payments-v2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Run the command against replayed fixtures
+			out, stderr, clip, err := runE2E(t, tt.args)
+			require.NoError(t, err)
+
+			// Assert: Both streams match their golden files and the clipboard holds the list
+			assertGolden(t, tt.name+".golden", out)
+			assertGolden(t, tt.name+".stderr.golden", stderr)
+			assert.Contains(t, out, clip)
+		})
+	}
+}
+
+// assertGolden compares actual with the golden file name of testdata/e2e,
+// rewriting it first with -update
+func assertGolden(t *testing.T, name, actual string) {
+	t.Helper()
+
+	golden := filepath.Join("testdata", "e2e", name)
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, []byte(actual), 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestEndToEndJSONIsParsable(t *testing.T) {
+	t.Parallel()
+
+	// Act: List a branch as JSON, as when piping into jq
+	out, _, _, err := runE2E(t, []string{"release/next", "--format", "json"})
+
+	// Assert: Status lines stay off stdout, which holds the JSON document only
+	require.NoError(t, err)
+	assert.True(t, json.Valid([]byte(out)), out)
+}
+
+func TestEndToEndMissingFixture(t *testing.T) {
+	t.Parallel()
+
+	// A request without a recorded fixture fails instead of reaching the network
+	_, _, _, err := runE2E(t, []string{"missing-fix"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded fixture")
+}
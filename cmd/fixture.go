@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Environment variables selecting the record/replay transports
+const (
+	recordEnv = "GH_TOPIC_URLS_RECORD"
+	replayEnv = "GH_TOPIC_URLS_REPLAY"
+)
+
+// fixtureNamePattern matches the characters replaced when deriving fixture file names
+var fixtureNamePattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// fixture is one recorded REST API interaction stored as a JSON file
+type fixture struct {
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// recordTransport passes requests to the next transport and writes each
+// response to a fixture file in dir
+type recordTransport struct {
+	next apiTransport
+	dir  string
+}
+
+// Get performs the request and records the response
+func (r *recordTransport) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	resp, err := r.next.Get(ctx, path, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.record(path, resp); err != nil {
+		return nil, fmt.Errorf("failed to record fixture for %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+func (r *recordTransport) record(path string, resp *apiResponse) error {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+
+	f := fixture{Path: path, Status: resp.StatusCode, Header: fixtureHeader(resp.Header)}
+
+	// JSON bodies are stored inline and indented so fixtures stay readable and editable
+	var body bytes.Buffer
+	switch {
+	case len(bytes.TrimSpace(resp.Body)) == 0:
+		f.Body = json.RawMessage("null")
+	case json.Indent(&body, resp.Body, "  ", "  ") == nil:
+		f.Body = body.Bytes()
+	default:
+		encoded, err := json.Marshal(string(resp.Body))
+		if err != nil {
+			return err
+		}
+		f.Body = encoded
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, fixtureFileName(path)), append(data, '\n'), 0o644)
+}
+
+// fixtureHeader keeps the response headers that matter for replay
+func fixtureHeader(header http.Header) http.Header {
+	kept := http.Header{}
+	for _, name := range []string{"Content-Type", "ETag", "Link"} {
+		if value := header.Get(name); value != "" {
+			kept.Set(name, value)
+		}
+	}
+	return kept
+}
+
+// fixtureFileName derives a readable, unique file name from a request path
+func fixtureFileName(path string) string {
+	slug := strings.Trim(fixtureNamePattern.ReplaceAllString(path, "-"), "-")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("%s-%s.json", slug, hex.EncodeToString(sum[:4]))
+}
+
+// replayTransport serves responses from the fixtures in dir, matched by request
+// path, and never touches the network
+type replayTransport struct {
+	dir string
+
+	once     sync.Once
+	fixtures map[string]*fixture
+	loadErr  error
+}
+
+// Get returns the recorded response for path
+func (r *replayTransport) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	r.once.Do(r.load)
+	if r.loadErr != nil {
+		return nil, r.loadErr
+	}
+
+	f, ok := r.fixtures[path]
+	if !ok {
+		return nil, fmt.Errorf("no recorded fixture for %s in %s", path, r.dir)
+	}
+
+	body := []byte(f.Body)
+	if string(body) == "null" {
+		body = nil
+	}
+	// Non-JSON bodies are recorded as JSON strings
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+
+	return &apiResponse{StatusCode: f.Status, Header: f.Header.Clone(), Body: body}, nil
+}
+
+// load reads every fixture file of the directory, so file names do not matter
+func (r *replayTransport) load() {
+	files, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		r.loadErr = fmt.Errorf("failed to list fixtures: %w", err)
+		return
+	}
+
+	r.fixtures = make(map[string]*fixture, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			r.loadErr = fmt.Errorf("failed to read fixture: %w", err)
+			return
+		}
+
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			r.loadErr = fmt.Errorf("failed to parse fixture %s: %w", file, err)
+			return
+		}
+		if f.Status == 0 {
+			f.Status = http.StatusOK
+		}
+		r.fixtures[f.Path] = &f
+	}
+}
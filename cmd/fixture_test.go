@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplayRoundTrip(t *testing.T) {
+	// Arrange: Record two responses, one of them an error
+	dir := t.TempDir()
+	next := (&fakeAPI{}).on("/repos/o/r/pulls?base=main&page=1", okResponse(`"v1"`, `[{"number":1}]`))
+	recorder := &recordTransport{next: next, dir: dir}
+
+	_, err := recorder.Get(context.Background(), "/repos/o/r/pulls?base=main&page=1", nil)
+	require.NoError(t, err)
+	_, err = recorder.Get(context.Background(), "/repos/o/missing/pulls", nil)
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// Act: Replay the recorded responses
+	replayer := &replayTransport{dir: dir}
+	ok, okErr := replayer.Get(context.Background(), "/repos/o/r/pulls?base=main&page=1", nil)
+	notFound, notFoundErr := replayer.Get(context.Background(), "/repos/o/missing/pulls", nil)
+	_, missingErr := replayer.Get(context.Background(), "/repos/o/r/pulls?base=main&page=2", nil)
+
+	// Assert: Status, headers and bodies survive the round trip
+	require.NoError(t, okErr)
+	assert.Equal(t, http.StatusOK, ok.StatusCode)
+	assert.Equal(t, `"v1"`, ok.Header.Get("ETag"))
+	assert.JSONEq(t, `[{"number":1}]`, string(ok.Body))
+
+	require.NoError(t, notFoundErr)
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode)
+	assert.JSONEq(t, `{"message":"Not Found"}`, string(notFound.Body))
+
+	assert.Error(t, missingErr)
+}
+
+func TestReplayTransportNonJSONBody(t *testing.T) {
+	dir := t.TempDir()
+	next := (&fakeAPI{}).on("/path", &apiResponse{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: []byte("<html>bad gateway</html>")})
+	recorder := &recordTransport{next: next, dir: dir}
+	_, err := recorder.Get(context.Background(), "/path", nil)
+	require.NoError(t, err)
+
+	resp, err := (&replayTransport{dir: dir}).Get(context.Background(), "/path", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "<html>bad gateway</html>", string(resp.Body))
+}
+
+func TestReplayTransportInvalidFixture(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644))
+
+	_, err := (&replayTransport{dir: dir}).Get(context.Background(), "/path", nil)
+
+	assert.Error(t, err)
+}
+
+func TestConfigureTransportReplay(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: The App's environment selects fixture replay
+	dir := t.TempDir()
+	app, _ := newTestApp(mockExecCommand("", nil))
+	app.Getenv = func(key string) string {
+		if key == replayEnv {
+			return dir
+		}
+		return ""
+	}
+
+	// Act: Configure the transport
+	require.NoError(t, app.configureTransport(false, 0, false))
+
+	// Assert: Responses are replayed, and GraphQL and writes are refused
+	assert.IsType(t, &replayTransport{}, app.API)
+	assert.Nil(t, app.cache)
+	_, err := app.ghGraphQL(context.Background(), "query{viewer{login}}", nil)
+	assert.EqualError(t, err, "GraphQL is not available offline")
+	_, err = app.ghAPIWrite(context.Background(), "POST", "/repos/owner/repo/issues/1/comments", nil)
+	assert.EqualError(t, err, "writing to GitHub is not available offline")
+}
+
+func TestFixtureFileName(t *testing.T) {
+	// Names are readable and differ for paths that slug to the same text
+	assert.Equal(t, "repos-o-r-pulls-", fixtureFileName("/repos/o/r/pulls")[:16])
+	assert.NotEqual(t, fixtureFileName("/repos/o/r/pulls?page=1"), fixtureFileName("/repos/o/r/pulls/page=1"))
+	assert.LessOrEqual(t, len(fixtureFileName("/"+string(make([]byte, 200)))), 100)
+}
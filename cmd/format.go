@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultFormat is the output format used when --format is not given
+const defaultFormat = "markdown"
+
+// report is the data rendered by a formatter
+type report struct {
+	Title    string
+	Sections []reportSection
+}
+
+// reportSection is a titled group of pull requests, e.g. one per repository.
+// Single-repository reports use one section without a title.
+type reportSection struct {
+	Title        string
+	Repo         string
+	Branch       string
+	PullRequests []pullRequest
+	Issues       []issueRef
+}
+
+// grouped reports whether the report has titled sections to render as groups
+func (r report) grouped() bool {
+	return r.Title != "" || len(r.Sections) != 1 || r.Sections[0].Title != ""
+}
+
+// formatter renders a report as text
+type formatter func(r report) (string, error)
+
+var formatters = map[string]formatter{
+	"markdown": formatMarkdown,
+	"plain":    formatPlain,
+	"json":     formatJSON,
+}
+
+// formatNames returns the registered format names in alphabetical order
+func formatNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateFormat checks that a formatter is registered under the given name
+func validateFormat(name string) error {
+	if _, ok := formatters[name]; !ok {
+		return fmt.Errorf("unknown format '%s' (available: %s)", name, strings.Join(formatNames(), ", "))
+	}
+	return nil
+}
+
+// renderReport renders a report with the named formatter
+func renderReport(name string, r report) (string, error) {
+	if err := validateFormat(name); err != nil {
+		return "", err
+	}
+	return formatters[name](r)
+}
+
+// formatMarkdown renders pull request URLs as a Markdown list followed by resolved issues
+func formatMarkdown(r report) (string, error) {
+	var sb strings.Builder
+	if r.Title != "" {
+		fmt.Fprintf(&sb, "# %s\n\n", r.Title)
+	}
+	for i, section := range r.Sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if section.Title != "" {
+			fmt.Fprintf(&sb, "## %s\n\n", section.Title)
+		}
+		sb.WriteString(formatPullRequestList(section.PullRequests))
+		sb.WriteString(formatIssueSection(section.Issues))
+	}
+	return sb.String(), nil
+}
+
+// formatPlain renders one URL per line, with resolved issues after a blank line
+func formatPlain(r report) (string, error) {
+	var sb strings.Builder
+	if r.Title != "" {
+		fmt.Fprintf(&sb, "%s\n\n", r.Title)
+	}
+	for i, section := range r.Sections {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if section.Title != "" {
+			fmt.Fprintln(&sb, section.Title)
+		}
+		for _, pr := range section.PullRequests {
+			fmt.Fprintln(&sb, pr.HTMLURL)
+		}
+		if len(section.Issues) > 0 {
+			sb.WriteString("\n")
+			for _, issue := range section.Issues {
+				fmt.Fprintln(&sb, issue.URL())
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+type jsonPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+	Merged bool   `json:"merged"`
+}
+
+type jsonIssue struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	URL        string `json:"url"`
+}
+
+type jsonSection struct {
+	Title          string            `json:"title,omitempty"`
+	Repository     string            `json:"repository,omitempty"`
+	Branch         string            `json:"branch,omitempty"`
+	PullRequests   []jsonPullRequest `json:"pull_requests"`
+	ResolvedIssues []jsonIssue       `json:"resolved_issues,omitempty"`
+}
+
+// formatJSON renders the report as an indented JSON document. Grouped reports
+// are rendered as a "repositories" array of per-repository documents.
+func formatJSON(r report) (string, error) {
+	sections := make([]jsonSection, 0, len(r.Sections))
+	for _, section := range r.Sections {
+		sections = append(sections, newJSONSection(section))
+	}
+
+	var doc any
+	switch {
+	case r.grouped():
+		doc = struct {
+			Title        string        `json:"title,omitempty"`
+			Repositories []jsonSection `json:"repositories"`
+		}{Title: r.Title, Repositories: sections}
+	default:
+		doc = sections[0]
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+func newJSONSection(section reportSection) jsonSection {
+	s := jsonSection{
+		Repository:   section.Repo,
+		Branch:       section.Branch,
+		PullRequests: make([]jsonPullRequest, 0, len(section.PullRequests)),
+	}
+
+	// Only aliases differing from the repository name are worth repeating
+	if section.Title != section.Repo {
+		s.Title = section.Title
+	}
+
+	for _, pr := range section.PullRequests {
+		s.PullRequests = append(s.PullRequests, jsonPullRequest{
+			Number: pr.Number,
+			Title:  pr.Title,
+			URL:    pr.HTMLURL,
+			State:  pr.State,
+			Merged: pr.MergedAt != nil,
+		})
+	}
+	for _, issue := range section.Issues {
+		s.ResolvedIssues = append(s.ResolvedIssues, jsonIssue{
+			Repository: issue.Repo,
+			Number:     issue.Number,
+			URL:        issue.URL(),
+		})
+	}
+	return s
+}
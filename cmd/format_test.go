@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderReport(t *testing.T) {
+	mergedAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	r := report{Sections: []reportSection{{
+		PullRequests: []pullRequest{
+			{Number: 1, Title: "Add feature", HTMLURL: "https://github.com/owner/repo/pull/1", State: "closed", MergedAt: &mergedAt},
+			{Number: 2, Title: "Fix bug", HTMLURL: "https://github.com/owner/repo/pull/2", State: "open"},
+		},
+		Issues: []issueRef{{Repo: "owner/repo", Number: 45}},
+	}}}
+
+	tests := []struct {
+		name        string
+		format      string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:   "Markdown",
+			format: "markdown",
+			expected: "- https://github.com/owner/repo/pull/1\n- https://github.com/owner/repo/pull/2\n" +
+				"\n### Resolved issues\n- https://github.com/owner/repo/issues/45\n",
+		},
+		{
+			name:   "Plain",
+			format: "plain",
+			expected: "https://github.com/owner/repo/pull/1\nhttps://github.com/owner/repo/pull/2\n" +
+				"\nhttps://github.com/owner/repo/issues/45\n",
+		},
+		{
+			name:   "JSON",
+			format: "json",
+			expected: `{
+  "pull_requests": [
+    {
+      "number": 1,
+      "title": "Add feature",
+      "url": "https://github.com/owner/repo/pull/1",
+      "state": "closed",
+      "merged": true
+    },
+    {
+      "number": 2,
+      "title": "Fix bug",
+      "url": "https://github.com/owner/repo/pull/2",
+      "state": "open",
+      "merged": false
+    }
+  ],
+  "resolved_issues": [
+    {
+      "repository": "owner/repo",
+      "number": 45,
+      "url": "https://github.com/owner/repo/issues/45"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:        "Unknown format",
+			format:      "xml",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Render report
+			result, err := renderReport(tt.format, r)
+
+			// Assert: Verify output
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatJSONWithoutPullRequests(t *testing.T) {
+	// An empty report still renders an empty array rather than null
+	result, err := formatJSON(report{Sections: []reportSection{{}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"pull_requests\": []\n}\n", result)
+}
+
+func TestRenderGroupedReport(t *testing.T) {
+	r := report{Sections: []reportSection{
+		{Title: "org/a", Repo: "org/a", PullRequests: []pullRequest{{Number: 1, HTMLURL: "https://github.com/org/a/pull/1"}}},
+		{Title: "org/b", Repo: "org/b", PullRequests: []pullRequest{{Number: 2, HTMLURL: "https://github.com/org/b/pull/2"}}},
+	}}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "Markdown",
+			format: "markdown",
+			expected: "## org/a\n\n- https://github.com/org/a/pull/1\n" +
+				"\n## org/b\n\n- https://github.com/org/b/pull/2\n",
+		},
+		{
+			name:   "Plain",
+			format: "plain",
+			expected: "org/a\nhttps://github.com/org/a/pull/1\n" +
+				"\norg/b\nhttps://github.com/org/b/pull/2\n",
+		},
+		{
+			name:   "JSON",
+			format: "json",
+			expected: `{
+  "repositories": [
+    {
+      "repository": "org/a",
+      "pull_requests": [
+        {
+          "number": 1,
+          "title": "",
+          "url": "https://github.com/org/a/pull/1",
+          "state": "",
+          "merged": false
+        }
+      ]
+    },
+    {
+      "repository": "org/b",
+      "pull_requests": [
+        {
+          "number": 2,
+          "title": "",
+          "url": "https://github.com/org/b/pull/2",
+          "state": "",
+          "merged": false
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Render grouped report
+			result, err := renderReport(tt.format, r)
+
+			// Assert: Verify output
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
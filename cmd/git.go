@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+)
+
+// Git backends selectable with --git-backend
+const (
+	gitBackendExec  = "exec"
+	gitBackendGoGit = "go-git"
+)
+
+// validateGitBackend checks the value of --git-backend
+func validateGitBackend(backend string) error {
+	switch backend {
+	case gitBackendExec, gitBackendGoGit:
+		return nil
+	default:
+		return fmt.Errorf("unknown git backend '%s' (available: %s, %s)", backend, gitBackendExec, gitBackendGoGit)
+	}
+}
+
+// openGitRepo opens the checkout in dir with the selected backend, each
+// operation bounded by requestTimeout. An empty dir means the current working directory.
+func (a *App) openGitRepo(dir string) (topicurls.GitRepo, error) {
+	if err := validateGitBackend(a.GitBackend); err != nil {
+		return nil, err
+	}
+	if a.GitBackend == gitBackendGoGit {
+		repo, err := topicurls.OpenGoGitRepo(dir)
+		if err != nil {
+			return nil, err
+		}
+		return timeoutGitRepo{repo: repo}, nil
+	}
+	return timeoutGitRepo{repo: topicurls.ExecGitRepo{Exec: a.Exec, Dir: dir}}, nil
+}
+
+func (a *App) getCurrentRepo(ctx context.Context) (string, error) {
+	return a.getRepoInDir(ctx, "")
+}
+
+// getRepoInDir resolves owner/repo from the origin remote of the checkout in dir.
+// An empty dir means the current working directory.
+func (a *App) getRepoInDir(ctx context.Context, dir string) (string, error) {
+	repo, err := a.openGitRepo(dir)
+	if err != nil {
+		return "", err
+	}
+
+	return topicurls.RepoFromRemote(ctx, repo, "origin")
+}
+
+func (a *App) getCurrentBranch(ctx context.Context) (string, error) {
+	repo, err := a.openGitRepo("")
+	if err != nil {
+		return "", err
+	}
+
+	return topicurls.DetectBranch(ctx, repo, a.Getenv)
+}
+
+func (a *App) branchExists(ctx context.Context, branchName string) (bool, error) {
+	return a.branchExistsInDir(ctx, "", branchName)
+}
+
+// branchExistsInDir checks for a local or origin branch in the checkout in dir.
+// An empty dir means the current working directory.
+func (a *App) branchExistsInDir(ctx context.Context, dir, branchName string) (bool, error) {
+	repo, err := a.openGitRepo(dir)
+	if err != nil {
+		return false, err
+	}
+
+	return repo.BranchExists(ctx, branchName)
+}
+
+// refExists reports whether ref names a branch, tag or commit in the local repository
+func (a *App) refExists(ctx context.Context, ref string) (bool, error) {
+	repo, err := a.openGitRepo("")
+	if err != nil {
+		return false, err
+	}
+
+	return repo.RefExists(ctx, ref)
+}
+
+// getAllBranches lists the local and remote branches, most recently committed first
+func (a *App) getAllBranches(ctx context.Context) ([]topicurls.Branch, error) {
+	repo, err := a.openGitRepo("")
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.Branches(ctx)
+}
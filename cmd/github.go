@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+)
+
+// Library types used throughout the commands
+type (
+	pullRequest  = topicurls.PullRequest
+	label        = topicurls.Label
+	apiResponse  = topicurls.Response
+	apiTransport = topicurls.Transport
+)
+
+// client returns a library client sending requests through the App's
+// transport, each bounded by requestTimeout
+func (a *App) client() *topicurls.Client {
+	return topicurls.NewClient(timeoutTransport{next: a.API})
+}
+
+// ghAPI performs a GET request against the GitHub REST API and returns the response body
+func (a *App) ghAPI(ctx context.Context, path string) ([]byte, error) {
+	return a.client().Get(ctx, path)
+}
+
+// ghAPIFresh is ghAPI for reads a write is built on: a cached response is
+// revalidated even within --cache-ttl, so the write never starts from stale data
+func (a *App) ghAPIFresh(ctx context.Context, path string) ([]byte, error) {
+	return a.ghAPI(withRevalidation(ctx), path)
+}
+
+// ghGraphQL runs a GraphQL query through the gh CLI and returns the raw response
+func (a *App) ghGraphQL(ctx context.Context, query string, variables map[string]string) ([]byte, error) {
+	// GraphQL requests are neither cached nor recorded, so they are unavailable
+	// offline and when replaying fixtures
+	if a.offline() || a.replay {
+		return nil, fmt.Errorf("GraphQL is not available offline")
+	}
+
+	// Variables are String! values such as owner and name; -f keeps a
+	// repository named 2048 or true from being sent as a number or boolean
+	args := []string{"api", "graphql", "-f", "query=" + query}
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		args = append(args, "-f", fmt.Sprintf("%s=%s", name, variables[name]))
+	}
+
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	cmd := a.Exec(ctx, "gh", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh api graphql error: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+// ghAPIWrite sends a REST request with string fields, such as a POST or
+// PATCH, through the gh CLI and returns the response body
+func (a *App) ghAPIWrite(ctx context.Context, method, path string, fields map[string]string) ([]byte, error) {
+	// Writes must reach GitHub, so they are refused offline and when replaying fixtures
+	if a.offline() || a.replay {
+		return nil, fmt.Errorf("writing to GitHub is not available offline")
+	}
+
+	args := []string{"api", "--method", method, path}
+	for name, value := range fields {
+		args = append(args, "-f", fmt.Sprintf("%s=%s", name, value))
+	}
+
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	cmd := a.Exec(ctx, "gh", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gh api error: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+// fetchPullRequests returns every pull request targeting branchName, oldest first
+func (a *App) fetchPullRequests(ctx context.Context, repo, branchName string) ([]pullRequest, error) {
+	return a.client().ListTopicPRs(ctx, repo, branchName, nil)
+}
+
+// formatPullRequestList renders pull requests as a Markdown list of URLs
+func formatPullRequestList(prs []pullRequest) string {
+	var sb strings.Builder
+	for _, pr := range prs {
+		fmt.Fprintf(&sb, "- %s\n", pr.HTMLURL)
+	}
+	return sb.String()
+}
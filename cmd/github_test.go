@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAPIOutput builds the output of "gh api -i" for a response with the given status and body
+func mockAPIOutput(status int, body string) string {
+	return fmt.Sprintf("HTTP/2.0 %d %s\r\nContent-Type: application/json\r\n\r\n%s", status, http.StatusText(status), body)
+}
+
+func TestFetchPullRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mockOutput  string
+		mockError   error
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:       "Single page of pull requests",
+			mockOutput: mockAPIOutput(200, `[{"number":1,"html_url":"https://github.com/owner/repo/pull/1"},{"number":2,"html_url":"https://github.com/owner/repo/pull/2"}]`),
+			expected:   []int{1, 2},
+		},
+		{
+			name:       "No pull requests",
+			mockOutput: mockAPIOutput(200, `[]`),
+			expected:   nil,
+		},
+		{
+			name:        "Unexpected JSON",
+			mockOutput:  mockAPIOutput(200, `{"message":"Moved"}`),
+			expectError: true,
+		},
+		{
+			name:        "Not found",
+			mockOutput:  mockAPIOutput(404, `{"message":"Not Found"}`),
+			expectError: true,
+		},
+		{
+			name:        "gh command error",
+			mockError:   fmt.Errorf("gh command failed"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Setup mock command execution
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
+
+			// Act: Fetch pull requests
+			prs, err := app.fetchPullRequests(context.Background(), "owner/repo", "main")
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var numbers []int
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+}
+
+func TestFormatPullRequestList(t *testing.T) {
+	t.Parallel()
+
+	prs := []pullRequest{
+		{Number: 1, HTMLURL: "https://github.com/owner/repo/pull/1"},
+		{Number: 2, HTMLURL: "https://github.com/owner/repo/pull/2"},
+	}
+
+	result := formatPullRequestList(prs)
+
+	assert.Equal(t, "- https://github.com/owner/repo/pull/1\n- https://github.com/owner/repo/pull/2\n", result)
+}
+
+func TestGHGraphQLSendsStringVariables(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A repository whose name looks like a number
+	recorder := &ghWriteRecorder{output: `{"data":{}}`}
+	app, _ := newTestApp(recorder.run)
+
+	// Act: Run a query for it
+	_, err := app.ghGraphQL(context.Background(), "query{}", map[string]string{"owner": "owner", "name": "2048"})
+
+	// Assert: The variables are sent as raw strings, not converted by -F
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api graphql -f query=query{} -f name=2048 -f owner=owner"}, recorder.calls)
+}
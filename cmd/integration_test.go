@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,21 +16,22 @@ func TestIntegrationBranchSelection(t *testing.T) {
 	// This test requires an actual git repository
 
 	ctx := context.Background()
+	app := newApp()
 
 	// Test getAllBranches works in a real git repo
-	branches, err := getAllBranches(ctx)
+	branches, err := app.getAllBranches(ctx)
 
 	// We should have at least one branch (the current one)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, branches)
 
 	// Verify current branch detection works
-	currentBranch, err := getCurrentBranch(ctx)
+	currentBranch, err := app.getCurrentBranch(ctx)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, currentBranch)
 
 	// Current branch should be in the branches list
-	assert.Contains(t, branches, currentBranch)
+	assert.Contains(t, topicurls.BranchNames(branches), currentBranch)
 
 	t.Logf("Found %d branches: %v", len(branches), branches)
 	t.Logf("Current branch: %s", currentBranch)
@@ -38,13 +40,14 @@ func TestIntegrationBranchSelection(t *testing.T) {
 func TestIntegrationNonInteractiveMode(t *testing.T) {
 	// Test non-interactive mode with actual git repo
 	ctx := context.Background()
+	app := newApp()
 
 	// Test with no args (should use current branch)
-	branchName, err := selectBranchForTopicUrls(ctx, []string{}, false)
+	branchName, err := app.selectBranchForTopicUrls(ctx, []string{})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, branchName)
 
-	currentBranch, _ := getCurrentBranch(ctx)
+	currentBranch, _ := app.getCurrentBranch(ctx)
 	assert.Equal(t, currentBranch, branchName)
 
 	t.Logf("Non-interactive mode selected branch: %s", branchName)
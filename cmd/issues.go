@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// closingIssuesBatchSize limits how many pull requests are queried per GraphQL request
+const closingIssuesBatchSize = 50
+
+// closingKeywordPattern matches GitHub closing keywords followed by an issue reference
+// such as "Closes #45", "fixes org/repo#12" or "Resolves https://github.com/org/repo/issues/7"
+var closingKeywordPattern = regexp.MustCompile(
+	`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\b:?\s+` +
+		`(?:https://github\.com/([\w.-]+/[\w.-]+)/issues/(\d+)|([\w.-]+/[\w.-]+)?#(\d+))`,
+)
+
+// issueRef identifies an issue in a repository
+type issueRef struct {
+	Repo   string
+	Number int
+}
+
+// URL returns the GitHub web URL of the issue
+func (i issueRef) URL() string {
+	return fmt.Sprintf("https://github.com/%s/issues/%d", i.Repo, i.Number)
+}
+
+func (i issueRef) key() string {
+	return fmt.Sprintf("%s#%d", strings.ToLower(i.Repo), i.Number)
+}
+
+// extractClosingIssues parses closing keywords from a pull request body.
+// References without an explicit repository are resolved against defaultRepo.
+func extractClosingIssues(body, defaultRepo string) []issueRef {
+	var refs []issueRef
+
+	for _, match := range closingKeywordPattern.FindAllStringSubmatch(body, -1) {
+		repo, number := match[1], match[2]
+		if number == "" {
+			repo, number = match[3], match[4]
+		}
+		if repo == "" {
+			repo = defaultRepo
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, issueRef{Repo: repo, Number: n})
+	}
+
+	return refs
+}
+
+// dedupeIssues removes duplicate issue references while keeping the first occurrence order
+func dedupeIssues(refs []issueRef) []issueRef {
+	seen := make(map[string]bool, len(refs))
+	result := make([]issueRef, 0, len(refs))
+
+	for _, ref := range refs {
+		if seen[ref.key()] {
+			continue
+		}
+		seen[ref.key()] = true
+		result = append(result, ref)
+	}
+
+	return result
+}
+
+// collectResolvedIssues gathers the issues closed by the given pull requests.
+// GraphQL closingIssuesReferences are used when available; keyword parsing of
+// the pull request bodies is always applied so nothing mentioned there is lost.
+func (a *App) collectResolvedIssues(ctx context.Context, repo string, prs []pullRequest) []issueRef {
+	var refs []issueRef
+
+	// GraphQL may be unavailable (e.g. older GitHub Enterprise Server); fall back to body parsing only
+	if linked, err := a.fetchClosingIssueReferences(ctx, repo, prs); err == nil {
+		refs = append(refs, linked...)
+	}
+
+	for _, pr := range prs {
+		refs = append(refs, extractClosingIssues(pr.Body, repo)...)
+	}
+
+	return dedupeIssues(refs)
+}
+
+// fetchClosingIssueReferences queries closingIssuesReferences for the given pull requests
+func (a *App) fetchClosingIssueReferences(ctx context.Context, repo string, prs []pullRequest) ([]issueRef, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository: %s", repo)
+	}
+
+	var refs []issueRef
+	for start := 0; start < len(prs); start += closingIssuesBatchSize {
+		end := min(start+closingIssuesBatchSize, len(prs))
+
+		output, err := a.ghGraphQL(ctx, buildClosingIssuesQuery(prs[start:end]), map[string]string{
+			"owner": owner,
+			"name":  name,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := parseClosingIssuesResponse(output, end-start)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, batch...)
+	}
+
+	return refs, nil
+}
+
+// buildClosingIssuesQuery builds a GraphQL query with one aliased field per pull request
+func buildClosingIssuesQuery(prs []pullRequest) string {
+	var sb strings.Builder
+	sb.WriteString("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) {")
+	for i, pr := range prs {
+		fmt.Fprintf(&sb, " pr%d: pullRequest(number: %d) { closingIssuesReferences(first: 50) { nodes { number repository { nameWithOwner } } } }", i, pr.Number)
+	}
+	sb.WriteString(" } }")
+	return sb.String()
+}
+
+// parseClosingIssuesResponse extracts issue references from a closingIssuesReferences response
+func parseClosingIssuesResponse(data []byte, count int) ([]issueRef, error) {
+	var response struct {
+		Data struct {
+			Repository map[string]*struct {
+				ClosingIssuesReferences struct {
+					Nodes []struct {
+						Number     int `json:"number"`
+						Repository struct {
+							NameWithOwner string `json:"nameWithOwner"`
+						} `json:"repository"`
+					} `json:"nodes"`
+				} `json:"closingIssuesReferences"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+
+	var refs []issueRef
+	for i := 0; i < count; i++ {
+		pr := response.Data.Repository[fmt.Sprintf("pr%d", i)]
+		if pr == nil {
+			continue
+		}
+		for _, node := range pr.ClosingIssuesReferences.Nodes {
+			refs = append(refs, issueRef{Repo: node.Repository.NameWithOwner, Number: node.Number})
+		}
+	}
+
+	return refs, nil
+}
+
+// formatIssueSection renders the "Resolved issues" section appended after the pull request list
+func formatIssueSection(refs []issueRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n### Resolved issues\n")
+	for _, ref := range refs {
+		fmt.Fprintf(&sb, "- %s\n", ref.URL())
+	}
+	return sb.String()
+}
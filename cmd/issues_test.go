@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractClosingIssues(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []issueRef
+	}{
+		{
+			name:     "Short reference",
+			body:     "Closes #45",
+			expected: []issueRef{{Repo: "owner/repo", Number: 45}},
+		},
+		{
+			name:     "Cross repository reference",
+			body:     "Fixes org/other#12",
+			expected: []issueRef{{Repo: "org/other", Number: 12}},
+		},
+		{
+			name:     "Issue URL",
+			body:     "Resolves https://github.com/org/other/issues/7",
+			expected: []issueRef{{Repo: "org/other", Number: 7}},
+		},
+		{
+			name: "Multiple keywords with mixed case and colon",
+			body: "This PR fixed: #1\n\nCLOSES #2 and resolved #3",
+			expected: []issueRef{
+				{Repo: "owner/repo", Number: 1},
+				{Repo: "owner/repo", Number: 2},
+				{Repo: "owner/repo", Number: 3},
+			},
+		},
+		{
+			name:     "Mention without closing keyword",
+			body:     "Related to #10, see also #11",
+			expected: nil,
+		},
+		{
+			name:     "Keyword as part of another word",
+			body:     "prefixes #10",
+			expected: nil,
+		},
+		{
+			name:     "Empty body",
+			body:     "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Extract issues from body
+			result := extractClosingIssues(tt.body, "owner/repo")
+
+			// Assert: Verify references
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDedupeIssues(t *testing.T) {
+	// Arrange: References with duplicates differing only in repository case
+	refs := []issueRef{
+		{Repo: "owner/repo", Number: 1},
+		{Repo: "org/other", Number: 1},
+		{Repo: "Owner/Repo", Number: 1},
+		{Repo: "owner/repo", Number: 2},
+	}
+
+	// Act: Dedupe references
+	result := dedupeIssues(refs)
+
+	// Assert: First occurrences are kept in order
+	assert.Equal(t, []issueRef{
+		{Repo: "owner/repo", Number: 1},
+		{Repo: "org/other", Number: 1},
+		{Repo: "owner/repo", Number: 2},
+	}, result)
+}
+
+func TestParseClosingIssuesResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		count       int
+		expected    []issueRef
+		expectError bool
+	}{
+		{
+			name: "Linked issues across pull requests",
+			response: `{"data":{"repository":{
+				"pr0":{"closingIssuesReferences":{"nodes":[{"number":5,"repository":{"nameWithOwner":"owner/repo"}}]}},
+				"pr1":{"closingIssuesReferences":{"nodes":[{"number":9,"repository":{"nameWithOwner":"org/other"}}]}}}}}`,
+			count: 2,
+			expected: []issueRef{
+				{Repo: "owner/repo", Number: 5},
+				{Repo: "org/other", Number: 9},
+			},
+		},
+		{
+			name:     "Missing pull request alias",
+			response: `{"data":{"repository":{"pr0":null}}}`,
+			count:    1,
+			expected: nil,
+		},
+		{
+			name:        "GraphQL error",
+			response:    `{"errors":[{"message":"Field 'closingIssuesReferences' doesn't exist"}]}`,
+			count:       1,
+			expectError: true,
+		},
+		{
+			name:        "Invalid JSON",
+			response:    `not json`,
+			count:       1,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Parse response
+			result, err := parseClosingIssuesResponse([]byte(tt.response), tt.count)
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatIssueSection(t *testing.T) {
+	// Empty input renders nothing
+	assert.Empty(t, formatIssueSection(nil))
+
+	// Issues render as a Markdown list under a heading
+	result := formatIssueSection([]issueRef{{Repo: "owner/repo", Number: 45}})
+	assert.Equal(t, "\n### Resolved issues\n- https://github.com/owner/repo/issues/45\n", result)
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// repoQueryConcurrency limits how many repositories are queried in parallel
+const repoQueryConcurrency = 4
+
+// reposPerPage is the page size requested from the organization repositories endpoint
+const reposPerPage = 100
+
+// repoNamePattern matches an "owner/name" repository identifier
+var repoNamePattern = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// repoQuery describes which branch to query in a repository and how to title it
+type repoQuery struct {
+	Repo   string
+	Branch string
+	Title  string
+}
+
+// repoResult holds the pull requests fetched from one repository
+type repoResult struct {
+	repoQuery
+	PullRequests []pullRequest
+	Err          error
+}
+
+// runMultiRepo queries several repositories for pull requests into the same topic branch
+func (a *App) runMultiRepo(ctx context.Context, opts rootOptions, args []string) error {
+	if opts.Interactive || opts.FromCommits != "" {
+		return fmt.Errorf("--repos, --org and --scan-dir cannot be combined with --interactive or --from-commits")
+	}
+	sources := 0
+	for _, set := range []bool{len(opts.Repos) > 0, opts.Org != "", opts.ScanDir != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of --repos, --org and --scan-dir can be used")
+	}
+
+	// The branch may not exist in the local checkout, so it is not validated here
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+		fmt.Fprintf(a.Stderr, "Target branch: %s\n", branchName)
+	} else {
+		current, err := a.getCurrentBranch(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get branch: %w\nUsage: gh-topic-urls --repos owner/a,owner/b [branch-name]", err)
+		}
+		branchName = current
+		fmt.Fprintf(a.Stderr, "Using current branch: %s\n", branchName)
+	}
+
+	repos, err := parseRepoList(opts.Repos)
+	if err != nil {
+		return err
+	}
+	if opts.Org != "" {
+		repos, err = a.fetchOrgRepos(ctx, opts.Org)
+		if err != nil {
+			return fmt.Errorf("failed to list repositories of '%s': %w", opts.Org, err)
+		}
+	}
+	if opts.ScanDir != "" {
+		repos, err = a.scanCheckoutsWithBranch(ctx, opts.ScanDir, branchName)
+		if err != nil {
+			return err
+		}
+		if len(repos) == 0 {
+			fmt.Fprintf(a.Stderr, "No checkouts under %s have branch '%s'\n", opts.ScanDir, branchName)
+			return nil
+		}
+	}
+
+	queries := make([]repoQuery, 0, len(repos))
+	for _, repo := range repos {
+		queries = append(queries, repoQuery{Repo: repo, Branch: branchName, Title: repo})
+	}
+
+	if err := a.getMultiRepoTopicUrls(ctx, report{}, queries); err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+	return nil
+}
+
+// parseRepoList validates and deduplicates "owner/name" repository identifiers
+func parseRepoList(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	repos := make([]string, 0, len(values))
+
+	for _, value := range values {
+		repo := strings.TrimSpace(value)
+		if repo == "" {
+			continue
+		}
+		if !repoNamePattern.MatchString(repo) {
+			return nil, fmt.Errorf("invalid repository '%s': expected owner/name", repo)
+		}
+		if seen[strings.ToLower(repo)] {
+			continue
+		}
+		seen[strings.ToLower(repo)] = true
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// fetchOrgRepos lists the non-archived repositories of an organization
+func (a *App) fetchOrgRepos(ctx context.Context, org string) ([]string, error) {
+	var repos []string
+
+	for page := 1; ; page++ {
+		output, err := a.ghAPI(ctx, fmt.Sprintf("/orgs/%s/repos?per_page=%d&page=%d", org, reposPerPage, page))
+		if err != nil {
+			return nil, err
+		}
+
+		var pageRepos []struct {
+			FullName string `json:"full_name"`
+			Archived bool   `json:"archived"`
+		}
+		if err := json.Unmarshal(output, &pageRepos); err != nil {
+			return nil, fmt.Errorf("failed to decode repositories: %w", err)
+		}
+
+		for _, repo := range pageRepos {
+			if !repo.Archived {
+				repos = append(repos, repo.FullName)
+			}
+		}
+		if len(pageRepos) < reposPerPage {
+			break
+		}
+	}
+
+	return repos, nil
+}
+
+// fetchMultiRepoPullRequests queries every repository concurrently, keeping the
+// input order. Only each request is bounded by requestTimeout, so the later
+// repositories of a large organization are not cut off by the earlier ones.
+func (a *App) fetchMultiRepoPullRequests(ctx context.Context, queries []repoQuery) []repoResult {
+	results := make([]repoResult, len(queries))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, repoQueryConcurrency)
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query repoQuery) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			prs, err := a.fetchPullRequests(ctx, query.Repo, query.Branch)
+			results[i] = repoResult{repoQuery: query, PullRequests: prs, Err: err}
+		}(i, query)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// getMultiRepoTopicUrls prints one combined list of pull requests grouped by repository.
+// base carries document-level fields such as the title; sections are added to it.
+// When some repositories fail, the list of the others is still output and an
+// error is returned afterwards, so the command exits with a failure status.
+func (a *App) getMultiRepoTopicUrls(ctx context.Context, base report, queries []repoQuery) error {
+	if len(queries) == 0 {
+		return fmt.Errorf("no repositories to query")
+	}
+
+	results := a.fetchMultiRepoPullRequests(ctx, queries)
+
+	groups := make([][]pullRequest, len(results))
+	for i, result := range results {
+		groups[i] = result.PullRequests
+	}
+	groups, err := a.pickGroups(groups)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		results[i].PullRequests = groups[i]
+	}
+
+	r, failed := a.buildMultiRepoReport(ctx, base, results)
+	if failed == len(results) {
+		return fmt.Errorf("all %d repositories failed", failed)
+	}
+
+	if len(r.Sections) == 0 {
+		fmt.Fprintf(a.Stderr, "No pull requests found in %d repositories\n", len(results)-failed)
+	} else if err := a.outputReport(ctx, r); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed; the list is incomplete", failed, len(results))
+	}
+	return nil
+}
+
+// buildMultiRepoReport adds per-repository results to a grouped report.
+// Failed repositories are reported on stderr and counted; empty ones are skipped.
+func (a *App) buildMultiRepoReport(ctx context.Context, r report, results []repoResult) (report, int) {
+	failed := 0
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Fprintf(a.Stderr, "⚠ %s: %v\n", result.Repo, result.Err)
+			continue
+		}
+		if len(result.PullRequests) == 0 {
+			continue
+		}
+
+		section := reportSection{
+			Title:        result.Title,
+			Repo:         result.Repo,
+			Branch:       result.Branch,
+			PullRequests: result.PullRequests,
+		}
+		if a.WithIssues {
+			section.Issues = a.collectResolvedIssues(ctx, result.Repo, result.PullRequests)
+		}
+		r.Sections = append(r.Sections, section)
+	}
+
+	return r, failed
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRepoList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		values      []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "Multiple repositories",
+			values:   []string{"org/a", "org/b"},
+			expected: []string{"org/a", "org/b"},
+		},
+		{
+			name:     "Whitespace, empty values and duplicates",
+			values:   []string{" org/a ", "", "ORG/A", "org/b.js"},
+			expected: []string{"org/a", "org/b.js"},
+		},
+		{
+			name:        "Missing owner",
+			values:      []string{"repo"},
+			expectError: true,
+		},
+		{
+			name:        "URL instead of name",
+			values:      []string{"https://github.com/org/a"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Parse repositories
+			result, err := parseRepoList(tt.values)
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFetchOrgRepos(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Setup mock command execution
+	app, _ := newTestApp(mockExecCommand(mockAPIOutput(200, `[{"full_name":"org/a"},{"full_name":"org/old","archived":true},{"full_name":"org/b"}]`), nil))
+
+	// Act: List organization repositories
+	repos, err := app.fetchOrgRepos(context.Background(), "org")
+
+	// Assert: Archived repositories are skipped
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org/a", "org/b"}, repos)
+}
+
+func TestFetchMultiRepoPullRequests(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Every repository returns the same pull request
+	app, _ := newTestApp(mockExecCommand(mockAPIOutput(200, `[{"number":1,"html_url":"https://github.com/org/a/pull/1"}]`), nil))
+
+	// Act: Query repositories
+	queries := []repoQuery{
+		{Repo: "org/a", Branch: "feature/payments-v2"},
+		{Repo: "org/b", Branch: "feature/payments-v2"},
+		{Repo: "org/c", Branch: "feature/payments-v2-c"},
+	}
+	results := app.fetchMultiRepoPullRequests(context.Background(), queries)
+
+	// Assert: Results keep the input order
+	assert.Len(t, results, 3)
+	for i, query := range queries {
+		assert.Equal(t, query, results[i].repoQuery)
+		assert.NoError(t, results[i].Err)
+		assert.Len(t, results[i].PullRequests, 1)
+	}
+}
+
+func TestBuildMultiRepoReport(t *testing.T) {
+	t.Parallel()
+
+	results := []repoResult{
+		{repoQuery: repoQuery{Repo: "org/a", Title: "org/a"}, PullRequests: []pullRequest{{Number: 1}}},
+		{repoQuery: repoQuery{Repo: "org/b", Title: "org/b"}},
+		{repoQuery: repoQuery{Repo: "org/c", Title: "org/c"}, Err: fmt.Errorf("not found")},
+		{repoQuery: repoQuery{Repo: "org/d", Title: "Service D"}, PullRequests: []pullRequest{{Number: 4}}},
+	}
+
+	// Arrange: Capture the warnings
+	app, out := newTestApp(mockExecCommand("", nil))
+
+	// Act: Build report
+	r, failed := app.buildMultiRepoReport(context.Background(), report{Title: "Payments v2"}, results)
+
+	// Assert: Empty and failed repositories are left out
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, "Payments v2", r.Title)
+	assert.Len(t, r.Sections, 2)
+	assert.Equal(t, "org/a", r.Sections[0].Title)
+	assert.Equal(t, "Service D", r.Sections[1].Title)
+	assert.Equal(t, "org/d", r.Sections[1].Repo)
+	assert.Equal(t, "⚠ org/c: not found\n", out.String())
+}
+
+func TestGetMultiRepoTopicUrlsPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: org/web fails while org/api has a pull request
+	app, out := newTestApp(mockExecCommand("", nil))
+	app.API = (&fakeAPI{}).
+		on("/repos/org/api/pulls?base=feature%2Fpayments-v2&direction=asc&page=1&per_page=100&sort=created&state=all",
+			okResponse("", `[{"number":41,"html_url":"https://github.com/org/api/pull/41"}]`)).
+		on("/repos/org/web/pulls?base=feature%2Fpayments-v2&direction=asc&page=1&per_page=100&sort=created&state=all",
+			&apiResponse{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: []byte(`{"message":"Bad Gateway"}`)})
+	queries := []repoQuery{
+		{Repo: "org/api", Branch: "feature/payments-v2", Title: "org/api"},
+		{Repo: "org/web", Branch: "feature/payments-v2", Title: "org/web"},
+	}
+
+	// Act: Query both repositories
+	err := app.getMultiRepoTopicUrls(context.Background(), report{}, queries)
+
+	// Assert: The list of org/api is output, and the failure makes the command fail
+	assert.EqualError(t, err, "1 of 2 repositories failed; the list is incomplete")
+	assert.Equal(t, "⚠ org/web: gh api error: HTTP 502: Bad Gateway\n## org/api\n\n- https://github.com/org/api/pull/41\n✨ Copied to clipboard\n", out.String())
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// notifyCommand returns the command showing a desktop notification on goos
+func notifyCommand(goos, title, message string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		return "osascript", []string{"-e", script}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{title, message}, nil
+	default:
+		return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
+
+// desktopNotify shows a desktop notification with the platform's notifier
+func desktopNotify(title, message string) error {
+	name, args, err := notifyCommand(runtime.GOOS, title, message)
+	if err != nil {
+		return err
+	}
+	return exec.Command(name, args...).Run()
+}
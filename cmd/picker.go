@@ -0,0 +1,382 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// branchPickerSize is the number of branches the picker shows at once
+const branchPickerSize = 10
+
+// branchPRCountLimit caps the pull request counts of the picker, so each costs one request
+const branchPRCountLimit = 100
+
+// errSelectionCancelled is returned when the picker is closed without choosing
+var errSelectionCancelled = errors.New("selection cancelled")
+
+var (
+	pickerActiveStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true)
+	pickerSelectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
+	pickerFaintStyle    = lipgloss.NewStyle().Faint(true)
+)
+
+// prCountMsg delivers the number of pull requests into a branch to the picker
+type prCountMsg struct {
+	branch string
+	count  int
+	err    error
+}
+
+// branchSource lets fuzzy.FindFrom search branch names
+type branchSource []topicurls.Branch
+
+func (s branchSource) String(i int) string { return s[i].Name }
+func (s branchSource) Len() int            { return len(s) }
+
+// branchPicker is a Bubble Tea model choosing one or more branches. Typing
+// filters the branches by fuzzy match, tab toggles a branch and enter confirms.
+// The number of pull requests into each visible branch is fetched lazily.
+type branchPicker struct {
+	filter   textinput.Model
+	branches []topicurls.Branch
+	// matches holds indexes into branches that match the filter, best first
+	matches []int
+	cursor  int
+	offset  int
+	// selected is keyed by branch name
+	selected map[string]bool
+	// counts holds the rendered pull request count per branch; an empty
+	// string means the count is being fetched
+	counts map[string]string
+	// countPRs returns the number of pull requests into a branch; nil disables counts
+	countPRs func(branch string) (int, error)
+	now      time.Time
+
+	chosen    []string
+	cancelled bool
+}
+
+// newBranchPicker returns a picker listing branches in the given order
+func newBranchPicker(branches []topicurls.Branch, now time.Time, countPRs func(branch string) (int, error)) branchPicker {
+	filter := textinput.New()
+	filter.Prompt = "> "
+	filter.Placeholder = "type to filter"
+	filter.Focus()
+
+	m := branchPicker{
+		filter:   filter,
+		branches: branches,
+		selected: make(map[string]bool),
+		counts:   make(map[string]string),
+		countPRs: countPRs,
+		now:      now,
+	}
+	m.refilter()
+	return m
+}
+
+func (m branchPicker) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.loadCounts())
+}
+
+func (m branchPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case prCountMsg:
+		m.counts[msg.branch] = formatPRCount(msg.count, msg.err)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, tea.Quit
+		case "enter":
+			m.chosen = m.choice()
+			if len(m.chosen) == 0 {
+				return m, nil
+			}
+			return m, tea.Quit
+		case "up", "ctrl+p":
+			m.move(-1)
+			return m, m.loadCounts()
+		case "down", "ctrl+n":
+			m.move(1)
+			return m, m.loadCounts()
+		case "tab":
+			if len(m.matches) > 0 {
+				name := m.branches[m.matches[m.cursor]].Name
+				m.selected[name] = !m.selected[name]
+				m.move(1)
+			}
+			return m, m.loadCounts()
+		}
+	}
+
+	query := m.filter.Value()
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	if m.filter.Value() != query {
+		m.refilter()
+		return m, tea.Batch(cmd, m.loadCounts())
+	}
+	return m, cmd
+}
+
+func (m branchPicker) View() string {
+	if m.chosen != nil || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Select branches (tab: toggle, enter: confirm, esc: cancel)\n")
+	b.WriteString(m.filter.View() + "\n")
+
+	visible := m.visible()
+	width := 0
+	for _, i := range visible {
+		width = max(width, len(m.branches[i].Name))
+	}
+
+	for n, i := range visible {
+		branch := m.branches[i]
+		mark := "[ ]"
+		if m.selected[branch.Name] {
+			mark = pickerSelectedStyle.Render("[x]")
+		}
+
+		name := fmt.Sprintf("%-*s", width, branch.Name)
+		pointer := " "
+		if m.offset+n == m.cursor {
+			pointer = "▸"
+			name = pickerActiveStyle.Render(name)
+		}
+
+		fmt.Fprintf(&b, "%s %s %s  %s\n", pointer, mark, name, pickerFaintStyle.Render(m.describe(branch)))
+	}
+
+	fmt.Fprintf(&b, "%s\n", pickerFaintStyle.Render(fmt.Sprintf("%d/%d branches, %d selected", len(m.matches), len(m.branches), len(m.choiceOf(m.selected)))))
+	return b.String()
+}
+
+// describe renders the metadata shown next to a branch
+func (m branchPicker) describe(branch topicurls.Branch) string {
+	var parts []string
+	if branch.Remote != "" {
+		parts = append(parts, branch.Remote)
+	}
+	if !branch.CommitDate.IsZero() {
+		parts = append(parts, relativeAge(branch.CommitDate, m.now))
+	}
+	if branch.Author != "" {
+		parts = append(parts, branch.Author)
+	}
+	if m.countPRs != nil {
+		count, ok := m.counts[branch.Name]
+		if !ok || count == "" {
+			count = "… PRs"
+		}
+		parts = append(parts, count)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// refilter recomputes the matches for the current filter and resets the cursor
+func (m *branchPicker) refilter() {
+	m.cursor, m.offset = 0, 0
+	m.matches = m.matches[:0]
+
+	query := m.filter.Value()
+	if query == "" {
+		for i := range m.branches {
+			m.matches = append(m.matches, i)
+		}
+		return
+	}
+	for _, match := range fuzzy.FindFrom(query, branchSource(m.branches)) {
+		m.matches = append(m.matches, match.Index)
+	}
+}
+
+// move shifts the cursor by delta, scrolling the visible window with it
+func (m *branchPicker) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.matches)-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+branchPickerSize {
+		m.offset = m.cursor - branchPickerSize + 1
+	}
+}
+
+// visible returns the indexes of the branches currently on screen
+func (m branchPicker) visible() []int {
+	return m.matches[m.offset:min(m.offset+branchPickerSize, len(m.matches))]
+}
+
+// loadCounts starts fetching the pull request counts of visible branches not fetched yet
+func (m branchPicker) loadCounts() tea.Cmd {
+	if m.countPRs == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, i := range m.visible() {
+		name := m.branches[i].Name
+		if _, ok := m.counts[name]; ok {
+			continue
+		}
+		m.counts[name] = ""
+		cmds = append(cmds, func() tea.Msg {
+			count, err := m.countPRs(name)
+			return prCountMsg{branch: name, count: count, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// choice returns the selected branches, or the one under the cursor when none is selected
+func (m branchPicker) choice() []string {
+	if chosen := m.choiceOf(m.selected); len(chosen) > 0 {
+		return chosen
+	}
+	if len(m.matches) == 0 {
+		return nil
+	}
+	return []string{m.branches[m.matches[m.cursor]].Name}
+}
+
+// choiceOf returns the names in selected, in listing order
+func (m branchPicker) choiceOf(selected map[string]bool) []string {
+	var names []string
+	for _, branch := range m.branches {
+		if selected[branch.Name] {
+			names = append(names, branch.Name)
+		}
+	}
+	return names
+}
+
+// formatPRCount renders a pull request count for the picker
+func formatPRCount(count int, err error) string {
+	switch {
+	case err != nil:
+		return "? PRs"
+	case count >= branchPRCountLimit:
+		return fmt.Sprintf("%d+ PRs", branchPRCountLimit)
+	case count == 1:
+		return "1 PR"
+	default:
+		return fmt.Sprintf("%d PRs", count)
+	}
+}
+
+// relativeAge renders the time elapsed since t, e.g. "3d ago"
+func relativeAge(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dw ago", int(d.Hours()/(24*7)))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
+	default:
+		return fmt.Sprintf("%dy ago", int(d.Hours()/(24*365)))
+	}
+}
+
+// selectBranchesInteractively lets the user pick one or more branches with the branch picker
+func (a *App) selectBranchesInteractively(ctx context.Context) ([]string, error) {
+	branches, err := a.getAllBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("no branches found")
+	}
+
+	final, err := tea.NewProgram(newBranchPicker(branches, a.Now(), a.branchPRCounter(ctx))).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	picker := final.(branchPicker)
+	if picker.cancelled {
+		return nil, errSelectionCancelled
+	}
+	return picker.chosen, nil
+}
+
+// branchPRCounter returns a function counting the pull requests into a branch
+// of the current repository, or nil when the repository cannot be determined
+func (a *App) branchPRCounter(ctx context.Context) func(branch string) (int, error) {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return nil
+	}
+
+	return func(branch string) (int, error) {
+		return a.client().CountTopicPRs(ctx, repo, branch, branchPRCountLimit)
+	}
+}
+
+// getBranchesTopicUrls lists the pull requests into several branches, one section per branch
+func (a *App) getBranchesTopicUrls(ctx context.Context, branches []string) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	groups := make([][]pullRequest, len(branches))
+	for i, branch := range branches {
+		prs, err := a.fetchPullRequests(ctx, repo, branch)
+		if err != nil {
+			return fmt.Errorf("%s: %w", branch, err)
+		}
+		if len(prs) == 0 {
+			fmt.Fprintf(a.Stderr, "No pull requests found for branch '%s'\n", branch)
+		}
+		groups[i] = prs
+	}
+	groups, err = a.pickGroups(groups)
+	if err != nil {
+		return err
+	}
+
+	var r report
+	for i, branch := range branches {
+		prs := groups[i]
+		if len(prs) == 0 {
+			continue
+		}
+
+		section := reportSection{Title: branch, Repo: repo, Branch: branch, PullRequests: prs}
+		if a.WithIssues {
+			section.Issues = a.collectResolvedIssues(ctx, repo, prs)
+		}
+		r.Sections = append(r.Sections, section)
+	}
+
+	if len(r.Sections) == 0 {
+		return nil
+	}
+	return a.outputReport(ctx, r)
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pickerBranches returns n branches named branch-01..branch-n, newest first
+func pickerBranches(n int) []topicurls.Branch {
+	branches := make([]topicurls.Branch, 0, n)
+	for i := 1; i <= n; i++ {
+		branches = append(branches, topicurls.Branch{
+			Name:       fmt.Sprintf("branch-%02d", i),
+			CommitDate: testClock.Add(-time.Duration(i) * time.Hour),
+			Author:     "Alice",
+		})
+	}
+	return branches
+}
+
+// newTestPicker returns a picker with a steady cursor, so typing does not schedule blinks
+func newTestPicker(branches []topicurls.Branch, countPRs func(string) (int, error)) branchPicker {
+	m := newBranchPicker(branches, testClock, countPRs)
+	m.filter.Cursor.SetMode(cursor.CursorStatic)
+	return m
+}
+
+// prCounter counts the pull requests of every branch as its name length and records the calls
+type prCounter struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *prCounter) count(branch string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, branch)
+	return len(branch), nil
+}
+
+// press sends keys to the picker and feeds back the pull request counts its commands fetch
+func press(m branchPicker, keys ...tea.KeyMsg) (branchPicker, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		var model tea.Model
+		model, cmd = m.Update(key)
+		m = model.(branchPicker)
+		m = deliverCounts(m, cmd)
+	}
+	return m, cmd
+}
+
+// deliverCounts runs cmd and passes the resulting pull request counts to the picker
+func deliverCounts(m branchPicker, cmd tea.Cmd) branchPicker {
+	if cmd == nil {
+		return m
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = deliverCounts(m, c)
+		}
+	case prCountMsg:
+		model, _ := m.Update(msg)
+		m = model.(branchPicker)
+	}
+	return m
+}
+
+func typed(text string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)} }
+
+var (
+	keyTab   = tea.KeyMsg{Type: tea.KeyTab}
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+func TestBranchPickerFilter(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Branches with similar names
+	branches := []topicurls.Branch{{Name: "main"}, {Name: "release/next"}, {Name: "feature/next-ui"}, {Name: "develop"}}
+	m := newTestPicker(branches, nil)
+
+	// Act: Type a fuzzy query
+	m, _ = press(m, typed("rlnxt"))
+
+	// Assert: Only the branches containing the letters in order remain
+	assert.Equal(t, []int{1}, m.matches)
+	m, _ = press(m, keyEnter)
+	assert.Equal(t, []string{"release/next"}, m.chosen)
+}
+
+func TestBranchPickerMultiSelect(t *testing.T) {
+	t.Parallel()
+
+	m := newTestPicker(pickerBranches(4), nil)
+
+	// Tab toggles the branch under the cursor and moves down
+	m, _ = press(m, keyDown, keyDown, keyTab)
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyUp}, keyTab)
+	m, cmd := press(m, keyEnter)
+
+	// The selection is returned in listing order
+	assert.Equal(t, []string{"branch-01", "branch-03"}, m.chosen)
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
+
+func TestBranchPickerCancel(t *testing.T) {
+	t.Parallel()
+
+	m, cmd := press(newTestPicker(pickerBranches(2), nil), keyEsc)
+
+	assert.True(t, m.cancelled)
+	assert.Nil(t, m.chosen)
+	assert.Equal(t, tea.Quit(), cmd())
+
+	// Enter does nothing when the filter matches no branch
+	m, cmd = press(newTestPicker(pickerBranches(2), nil), typed("zzz"), keyEnter)
+	assert.Nil(t, m.chosen)
+	assert.Nil(t, cmd)
+}
+
+func TestBranchPickerLazyCounts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: More branches than fit on screen
+	counter := &prCounter{}
+	m := newTestPicker(pickerBranches(branchPickerSize+2), counter.count)
+
+	// Act: Start the picker
+	m = deliverCounts(m, m.Init())
+
+	// Assert: Only the visible branches were counted
+	assert.Len(t, counter.calls, branchPickerSize)
+	assert.Contains(t, m.View(), "branch-01  1h ago · Alice · 9 PRs")
+
+	// Scrolling past the last visible branch counts the next one, once
+	m, _ = press(m, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown)
+	assert.Len(t, counter.calls, branchPickerSize+1)
+	assert.Equal(t, "branch-11", counter.calls[branchPickerSize])
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyUp})
+	assert.Len(t, counter.calls, branchPickerSize+1)
+	assert.Contains(t, m.View(), "12 branches, 0 selected")
+}
+
+func TestBranchPickerView(t *testing.T) {
+	t.Parallel()
+
+	branches := []topicurls.Branch{
+		{Name: "main", CommitDate: testClock.Add(-30 * time.Minute), Author: "Bob"},
+		{Name: "release/next", Remote: "origin", CommitDate: testClock.Add(-50 * time.Hour), Author: "Alice"},
+	}
+	m := newTestPicker(branches, func(string) (int, error) { return 0, fmt.Errorf("offline") })
+	m = deliverCounts(m, m.Init())
+
+	view := m.View()
+
+	assert.Contains(t, view, "main          30m ago · Bob · ? PRs")
+	assert.Contains(t, view, "release/next  origin · 2d ago · Alice · ? PRs")
+}
+
+func TestRelativeAge(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		age      time.Duration
+		expected string
+	}{
+		{age: 10 * time.Second, expected: "just now"},
+		{age: 5 * time.Minute, expected: "5m ago"},
+		{age: 3 * time.Hour, expected: "3h ago"},
+		{age: 50 * time.Hour, expected: "2d ago"},
+		{age: 15 * 24 * time.Hour, expected: "2w ago"},
+		{age: 100 * 24 * time.Hour, expected: "3mo ago"},
+		{age: 800 * 24 * time.Hour, expected: "2y ago"},
+	} {
+		assert.Equal(t, tt.expected, relativeAge(testClock.Add(-tt.age), testClock))
+	}
+}
+
+func TestSelectBranchesInteractivelyErrors(t *testing.T) {
+	t.Parallel()
+
+	// Listing the branches fails
+	app, _ := newTestApp(mockExecCommand("", fmt.Errorf("git command failed")))
+	_, err := app.selectBranchesInteractively(context.Background())
+	assert.Error(t, err)
+
+	// The repository has no branches
+	app, _ = newTestApp(mockExecCommand("", nil))
+	_, err = app.selectBranchesInteractively(context.Background())
+	assert.EqualError(t, err, "no branches found")
+}
+
+func TestGetBranchesTopicUrls(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Pull requests into main and release/next, none into develop
+	app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+	app.API = newFakeAPI(map[string]string{
+		"base=main":           `[{"number":1,"html_url":"https://github.com/owner/repo/pull/1"}]`,
+		"base=develop":        `[]`,
+		"base=release%2Fnext": `[{"number":2,"html_url":"https://github.com/owner/repo/pull/2"}]`,
+	})
+
+	// Act: Report the three branches
+	err := app.getBranchesTopicUrls(context.Background(), []string{"main", "develop", "release/next"})
+
+	// Assert: One section per branch with pull requests
+	require.NoError(t, err)
+	assert.Equal(t, "No pull requests found for branch 'develop'\n"+
+		"## main\n\n- https://github.com/owner/repo/pull/1\n\n"+
+		"## release/next\n\n- https://github.com/owner/repo/pull/2\n"+
+		"✨ Copied to clipboard\n", out.String())
+}
+
+func TestBranchPRCounter(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: main has two pull requests
+	app, _ := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+	app.API = newFakeAPI(map[string]string{"base=main": `[{"number":1},{"number":2}]`})
+	count := app.branchPRCounter(context.Background())
+	require.NotNil(t, count)
+
+	// Act: Count the pull requests of a branch scrolled into view
+	n, err := count("main")
+
+	// Assert: The count is still fetched, with a single request
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"/repos/owner/repo/pulls?base=main&per_page=100&state=all"}, app.API.(*fakeAPI).paths())
+}
+
+func TestFormatPRCount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1 PR", formatPRCount(1, nil))
+	assert.Equal(t, "12 PRs", formatPRCount(12, nil))
+	assert.Equal(t, "100+ PRs", formatPRCount(branchPRCountLimit, nil))
+	assert.Equal(t, "? PRs", formatPRCount(0, errors.New("gh: not found")))
+}
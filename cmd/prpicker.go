@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prPickerSize is the number of pull requests the picker shows at once
+const prPickerSize = 15
+
+// prPicker is a Bubble Tea checklist of fetched pull requests, all selected at
+// first. Space toggles a pull request, a toggles all of them and enter confirms.
+type prPicker struct {
+	prs []pullRequest
+	// labels identify the pull requests, qualified with their repository
+	// when they come from several repositories
+	labels   []string
+	selected []bool
+	cursor   int
+	offset   int
+
+	confirmed bool
+	cancelled bool
+}
+
+// newPRPicker returns a picker with every pull request selected
+func newPRPicker(prs []pullRequest) prPicker {
+	repos := make(map[string]bool)
+	for _, pr := range prs {
+		repos[pullRequestRepo(pr)] = true
+	}
+
+	labels := make([]string, len(prs))
+	selected := make([]bool, len(prs))
+	for i, pr := range prs {
+		labels[i] = fmt.Sprintf("#%d", pr.Number)
+		if len(repos) > 1 {
+			labels[i] = pullRequestRepo(pr) + labels[i]
+		}
+		selected[i] = true
+	}
+	return prPicker{prs: prs, labels: labels, selected: selected}
+}
+
+func (m prPicker) Init() tea.Cmd {
+	return nil
+}
+
+func (m prPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "ctrl+c", "esc", "q":
+		m.cancelled = true
+		return m, tea.Quit
+	case "enter":
+		m.confirmed = true
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case " ", "x":
+		if len(m.prs) > 0 {
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		}
+	case "a":
+		all := len(m.chosen()) < len(m.prs)
+		for i := range m.selected {
+			m.selected[i] = all
+		}
+	}
+	return m, nil
+}
+
+func (m prPicker) View() string {
+	if m.confirmed || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Select pull requests to copy (space: toggle, a: all, enter: confirm, esc: cancel)\n")
+
+	end := min(m.offset+prPickerSize, len(m.prs))
+	width := 0
+	for _, label := range m.labels[m.offset:end] {
+		width = max(width, len(label))
+	}
+
+	for i := m.offset; i < end; i++ {
+		pr := m.prs[i]
+		mark := "[ ]"
+		if m.selected[i] {
+			mark = pickerSelectedStyle.Render("[x]")
+		}
+
+		line := fmt.Sprintf("%-*s %s", width, m.labels[i], pr.Title)
+		pointer := " "
+		if i == m.cursor {
+			pointer = "▸"
+			line = pickerActiveStyle.Render(line)
+		}
+
+		fmt.Fprintf(&b, "%s %s %s  %s\n", pointer, mark, line, pickerFaintStyle.Render(pullRequestState(pr)))
+	}
+
+	fmt.Fprintf(&b, "%s\n", pickerFaintStyle.Render(fmt.Sprintf("%d/%d selected", len(m.chosen()), len(m.prs))))
+	return b.String()
+}
+
+// move shifts the cursor by delta, scrolling the visible window with it
+func (m *prPicker) move(delta int) {
+	if len(m.prs) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.prs)-1)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+prPickerSize {
+		m.offset = m.cursor - prPickerSize + 1
+	}
+}
+
+// chosen returns the selected pull requests in their original order
+func (m prPicker) chosen() []pullRequest {
+	var prs []pullRequest
+	for i, pr := range m.prs {
+		if m.selected[i] {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// pullRequestState returns "merged" for merged pull requests and the API state otherwise
+func pullRequestState(pr pullRequest) string {
+	if pr.MergedAt != nil {
+		return "merged"
+	}
+	return pr.State
+}
+
+// pullRequestRepo returns the owner/repo part of a pull request URL
+func pullRequestRepo(pr pullRequest) string {
+	path := strings.TrimPrefix(pr.HTMLURL, "https://github.com/")
+	repo, _, _ := strings.Cut(path, "/pull/")
+	return repo
+}
+
+// runPRPicker lets the user choose among prs in the terminal
+func runPRPicker(prs []pullRequest) ([]pullRequest, error) {
+	final, err := tea.NewProgram(newPRPicker(prs)).Run()
+	if err != nil {
+		return nil, err
+	}
+
+	picker := final.(prPicker)
+	if picker.cancelled {
+		return nil, errSelectionCancelled
+	}
+	return picker.chosen(), nil
+}
+
+// pickPullRequests returns the pull requests chosen with PickPullRequests when
+// --pick is set, and prs unchanged otherwise
+func (a *App) pickPullRequests(prs []pullRequest) ([]pullRequest, error) {
+	if !a.Pick || len(prs) == 0 {
+		return prs, nil
+	}
+
+	picked, err := a.PickPullRequests(prs)
+	if err != nil {
+		return nil, fmt.Errorf("pull request selection failed: %w", err)
+	}
+	return picked, nil
+}
+
+// pickGroups applies pickPullRequests to the pull requests of several groups
+// at once, e.g. one per repository, and returns each group narrowed to the choice
+func (a *App) pickGroups(groups [][]pullRequest) ([][]pullRequest, error) {
+	if !a.Pick {
+		return groups, nil
+	}
+
+	var all []pullRequest
+	for _, prs := range groups {
+		all = append(all, prs...)
+	}
+	picked, err := a.pickPullRequests(all)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(picked))
+	for _, pr := range picked {
+		keep[pr.HTMLURL] = true
+	}
+	narrowed := make([][]pullRequest, len(groups))
+	for i, prs := range groups {
+		for _, pr := range prs {
+			if keep[pr.HTMLURL] {
+				narrowed[i] = append(narrowed[i], pr)
+			}
+		}
+	}
+	return narrowed, nil
+}
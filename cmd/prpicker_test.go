@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pickerPullRequests returns an open, a merged and a closed pull request of owner/repo
+func pickerPullRequests() []pullRequest {
+	merged := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	return []pullRequest{
+		{Number: 7, Title: "Add login", State: "open", HTMLURL: "https://github.com/owner/repo/pull/7"},
+		{Number: 12, Title: "Fix typo", State: "closed", MergedAt: &merged, HTMLURL: "https://github.com/owner/repo/pull/12"},
+		{Number: 15, Title: "Drop legacy API", State: "closed", HTMLURL: "https://github.com/owner/repo/pull/15"},
+	}
+}
+
+// pressPR sends keys to the pull request picker
+func pressPR(m prPicker, keys ...string) (prPicker, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+		}
+
+		var model tea.Model
+		model, cmd = m.Update(msg)
+		m = model.(prPicker)
+	}
+	return m, cmd
+}
+
+func TestPRPicker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		keys     []string
+		expected []int
+	}{
+		{name: "Everything is selected at first", keys: []string{"enter"}, expected: []int{7, 12, 15}},
+		{name: "Space drops the pull request under the cursor", keys: []string{"down", " ", "enter"}, expected: []int{7, 15}},
+		{name: "a clears a full selection", keys: []string{"a", "enter"}, expected: nil},
+		{name: "a selects all after a change", keys: []string{" ", "a", "enter"}, expected: []int{7, 12, 15}},
+		{name: "Vim keys move the cursor", keys: []string{"j", "j", "k", "x", "enter"}, expected: []int{7, 15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Press the keys
+			m, cmd := pressPR(newPRPicker(pickerPullRequests()), tt.keys...)
+
+			// Assert: The chosen pull requests, in their original order
+			assert.True(t, m.confirmed)
+			assert.Equal(t, tea.Quit(), cmd())
+			var numbers []int
+			for _, pr := range m.chosen() {
+				numbers = append(numbers, pr.Number)
+			}
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+}
+
+func TestPRPickerView(t *testing.T) {
+	t.Parallel()
+
+	m, _ := pressPR(newPRPicker(pickerPullRequests()), "down", " ")
+	view := m.View()
+
+	assert.Contains(t, view, "  [x] #7  Add login  open")
+	assert.Contains(t, view, "▸ [ ] #12 Fix typo  merged")
+	assert.Contains(t, view, "  [x] #15 Drop legacy API  closed")
+	assert.Contains(t, view, "2/3 selected")
+
+	// Pull requests of several repositories are labelled with their repository
+	prs := append(pickerPullRequests(), pullRequest{Number: 3, Title: "Share tokens", State: "open", HTMLURL: "https://github.com/owner/web/pull/3"})
+	view = newPRPicker(prs).View()
+	assert.Contains(t, view, "owner/repo#7  Add login")
+	assert.Contains(t, view, "owner/web#3   Share tokens")
+
+	// The picker clears once closed
+	m, _ = pressPR(m, "esc")
+	assert.True(t, m.cancelled)
+	assert.Empty(t, m.View())
+}
+
+func TestOutputPullRequestsPick(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		pick          bool
+		picked        []int
+		pickErr       error
+		expectedOut   string
+		expectedError bool
+	}{
+		{
+			name:        "Without --pick every pull request is output",
+			expectedOut: "- https://github.com/owner/repo/pull/7\n- https://github.com/owner/repo/pull/12\n- https://github.com/owner/repo/pull/15\n✨ Copied to clipboard\n",
+		},
+		{
+			name:        "Only the picked pull requests are output",
+			pick:        true,
+			picked:      []int{7, 15},
+			expectedOut: "- https://github.com/owner/repo/pull/7\n- https://github.com/owner/repo/pull/15\n✨ Copied to clipboard\n",
+		},
+		{
+			name:        "Nothing is copied when nothing is picked",
+			pick:        true,
+			expectedOut: "No pull requests selected\n",
+		},
+		{
+			name:          "Cancelling fails the command",
+			pick:          true,
+			pickErr:       errSelectionCancelled,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: A picker keeping tt.picked
+			app, out := newTestApp(mockExecCommand("", nil))
+			app.Pick = tt.pick
+			var clip string
+			app.Clipboard = func(text string) error {
+				clip = text
+				return nil
+			}
+			app.PickPullRequests = func(prs []pullRequest) ([]pullRequest, error) {
+				var picked []pullRequest
+				for _, pr := range prs {
+					for _, n := range tt.picked {
+						if pr.Number == n {
+							picked = append(picked, pr)
+						}
+					}
+				}
+				return picked, tt.pickErr
+			}
+
+			// Act: Output the fetched pull requests
+			err := app.outputPullRequests(context.Background(), "owner/repo", pickerPullRequests())
+
+			// Assert: Only the picked pull requests are printed and copied
+			if tt.expectedError {
+				assert.ErrorIs(t, err, errSelectionCancelled)
+				assert.Empty(t, clip)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOut, out.String())
+			assert.Contains(t, tt.expectedOut, clip)
+		})
+	}
+}
+
+func TestPickGroups(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Pull requests of two repositories, the first of each picked
+	app, _ := newTestApp(mockExecCommand("", nil))
+	app.Pick = true
+	var offered []pullRequest
+	app.PickPullRequests = func(prs []pullRequest) ([]pullRequest, error) {
+		offered = prs
+		return []pullRequest{prs[0], prs[2]}, nil
+	}
+	api := []pullRequest{{Number: 1, HTMLURL: "https://github.com/org/api/pull/1"}, {Number: 2, HTMLURL: "https://github.com/org/api/pull/2"}}
+	web := []pullRequest{{Number: 1, HTMLURL: "https://github.com/org/web/pull/1"}}
+
+	// Act: Pick across both groups
+	groups, err := app.pickGroups([][]pullRequest{api, web})
+
+	// Assert: One checklist for all groups, narrowed per group
+	require.NoError(t, err)
+	assert.Len(t, offered, 3)
+	assert.Equal(t, [][]pullRequest{api[:1], web}, groups)
+
+	// Without --pick the groups are returned unchanged
+	app.Pick = false
+	app.PickPullRequests = func([]pullRequest) ([]pullRequest, error) { return nil, fmt.Errorf("not called") }
+	groups, err = app.pickGroups([][]pullRequest{api, web})
+	require.NoError(t, err)
+	assert.Equal(t, [][]pullRequest{api, web}, groups)
+}
@@ -1,242 +1,358 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"os/signal"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
-	"github.com/manifoldco/promptui"
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
 	"github.com/spf13/cobra"
 )
 
-// Command execution variable for dependency injection in tests
-var execCommand = exec.CommandContext
-
-var interactiveMode bool
-
-var rootCmd = &cobra.Command{
-	Use:               "topic-urls",
-	Short:             "GitHub Topic Urls",
-	RunE:              runTopicUrls,
-	SilenceUsage:      true,
-	SilenceErrors:     true,
-	ValidArgsFunction: branchCompletion,
+// rootOptions holds the flags of the root command
+type rootOptions struct {
+	Interactive    bool
+	Pick           bool
+	Web            bool
+	Open           bool
+	OpenLimit      int
+	CommentOn      int
+	CommentOnIssue int
+	UpdateComment  bool
+	UpdateBody     int
+	DryRun         bool
+	Watch          bool
+	Interval       time.Duration
+	Notify         bool
+	NoVerify       bool
+	Auto           bool
+	TopicPattern   string
+	FromCommits    string
+	TopicBranch    string
+	Repos          []string
+	Org            string
+	ScanDir        string
 }
 
-func init() {
-	rootCmd.Flags().BoolVarP(&interactiveMode, "interactive", "i", false, "Interactive branch selection")
+// globalOptions holds the persistent flags shared by every command
+type globalOptions struct {
+	Format     string
+	GitBackend string
+	WithIssues bool
+	NoCache    bool
+	Offline    bool
+	CacheTTL   time.Duration
 }
 
-func runTopicUrls(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	branchName, err := selectBranchForTopicUrls(ctx, args, interactiveMode)
-	if err != nil {
-		if interactiveMode {
-			return fmt.Errorf("branch selection failed: %w", err)
-		}
-		return fmt.Errorf("failed to get branch: %w\nUsage: gh-topic-urls [branch-name] or gh-topic-urls -i", err)
-	}
-
-	if interactiveMode {
-		fmt.Printf("Selected branch: %s\n", branchName)
-	} else if len(args) < 1 {
-		fmt.Printf("Using current branch: %s\n", branchName)
-	} else {
-		fmt.Printf("Target branch: %s\n", branchName)
-	}
-
-	if err := getTopicUrls(ctx, branchName); err != nil {
-		return fmt.Errorf("failed to get pull requests: %w", err)
+// newRootCmd builds the command tree with its flags bound to fresh options,
+// so every run starts from the defaults
+func newRootCmd() *cobra.Command {
+	var globals globalOptions
+	var opts rootOptions
+
+	rootCmd := &cobra.Command{
+		Use:   "topic-urls",
+		Short: "GitHub Topic Urls",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTopicUrls(cmd, opts, args)
+		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return setupCommand(cmd, globals)
+		},
+		PersistentPostRun: printOfflineNotice,
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: branchCompletion,
 	}
 
-	return nil
+	rootCmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "Pick one or more branches with a fuzzy-searchable picker")
+	rootCmd.PersistentFlags().StringVarP(&globals.Format, "format", "f", defaultFormat, fmt.Sprintf("Output format (%s)", strings.Join(formatNames(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&globals.GitBackend, "git-backend", gitBackendExec, fmt.Sprintf("Git implementation to use (%s, %s)", gitBackendExec, gitBackendGoGit))
+	rootCmd.PersistentFlags().BoolVar(&globals.WithIssues, "with-issues", false, "Append issues closed by the pull requests")
+	rootCmd.Flags().BoolVar(&opts.Pick, "pick", false, "Choose which of the fetched pull requests to output from a checklist")
+	rootCmd.Flags().BoolVar(&opts.Web, "web", false, "Open the pull request search page of the branch in the browser instead of listing it")
+	rootCmd.Flags().BoolVar(&opts.Open, "open", false, "Open each listed pull request in the browser")
+	rootCmd.Flags().IntVar(&opts.OpenLimit, "open-limit", defaultOpenLimit, "Ask before --open opens more pull requests than this (0 never asks)")
+	rootCmd.Flags().IntVar(&opts.CommentOn, "comment-on", 0, "Post the output as a comment on this pull request of the current repository")
+	rootCmd.Flags().IntVar(&opts.CommentOnIssue, "comment-on-issue", 0, "Post the output as a comment on this issue of the current repository")
+	rootCmd.Flags().BoolVar(&opts.UpdateComment, "update-comment", false, "Edit the comment posted by a previous --comment-on run instead of adding one")
+	rootCmd.Flags().IntVar(&opts.UpdateBody, "update-body", 0, "Replace the marked section of this pull request's description with the output")
+	rootCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the --update-body change as a diff without applying it")
+	rootCmd.Flags().BoolVar(&opts.Watch, "watch", false, "Keep polling the branch and print the pull requests added, merged or closed")
+	rootCmd.Flags().DurationVar(&opts.Interval, "interval", defaultWatchInterval, "Polling interval of --watch")
+	rootCmd.Flags().BoolVar(&opts.Notify, "notify", false, "Show a desktop notification when --watch sees a change")
+	rootCmd.Flags().BoolVar(&opts.NoVerify, "no-verify", false, "Query the branch without checking that it exists locally or on GitHub")
+	rootCmd.Flags().StringVar(&opts.TopicBranch, "topic-branch", "", "Topic branch to query (alternative to the branch argument)")
+	rootCmd.Flags().StringSliceVar(&opts.Repos, "repos", nil, "Query these repositories (owner/name, comma separated) instead of the current one")
+	rootCmd.Flags().StringVar(&opts.Org, "org", "", "Query every repository of this organization")
+	rootCmd.Flags().StringVar(&opts.ScanDir, "scan-dir", "", "Query the repositories checked out under this directory that have the topic branch")
+	rootCmd.Flags().BoolVar(&opts.Auto, "auto", false, "When the current branch has no incoming pull requests, list the pull requests into its inferred base branch")
+	rootCmd.Flags().StringVar(&opts.TopicPattern, "topic-pattern", "", "Regular expression deriving the topic branch from a branch name for --auto (default $"+topicPatternEnv+")")
+	rootCmd.Flags().StringVar(&opts.FromCommits, "from-commits", "", "List merged pull requests that contributed commits in a git range (e.g. main..release/next)")
+	rootCmd.PersistentFlags().BoolVar(&globals.NoCache, "no-cache", false, "Do not read or write the API response cache")
+	rootCmd.PersistentFlags().BoolVar(&globals.Offline, "offline", false, "Serve API responses from the cache only, without network access")
+	rootCmd.PersistentFlags().DurationVar(&globals.CacheTTL, "cache-ttl", 0, "Serve cached responses younger than this without revalidation (e.g. 10m)")
+
+	rootCmd.AddCommand(
+		newCacheCmd(),
+		newChangelogCmd(),
+		newDiffCmd(),
+		newSnapshotCmd(),
+		newTUICmd(),
+		newWorkspaceCmd(),
+	)
+	return rootCmd
 }
 
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
+// setupCommand applies the persistent flags to the App and prepares its API transport
+func setupCommand(cmd *cobra.Command, globals globalOptions) error {
+	app := appFrom(cmd)
+
+	if err := validateGitBackend(globals.GitBackend); err != nil {
+		return err
 	}
+	app.GitBackend = globals.GitBackend
+	app.Format = globals.Format
+	app.WithIssues = globals.WithIssues
+
+	return app.configureTransport(globals.NoCache, globals.CacheTTL, globals.Offline)
 }
 
-// parseRepoFromURL extracts owner/repo from Git remote URL
-func parseRepoFromURL(remoteURL string) (string, error) {
-	// Handle SSH URL format: git@github.com:owner/repo.git
-	if strings.HasPrefix(remoteURL, "git@") {
-		parts := strings.Split(remoteURL, ":")
-		if len(parts) >= 2 {
-			repoPath := parts[len(parts)-1]
-			repoPath = strings.TrimSuffix(repoPath, ".git")
-			// Validate that repo path is not empty
-			if repoPath != "" {
-				return repoPath, nil
-			}
-		}
-	}
+func runTopicUrls(cmd *cobra.Command, opts rootOptions, args []string) error {
+	app := appFrom(cmd)
 
-	// Handle HTTPS URL format: https://github.com/owner/repo.git
-	if strings.HasPrefix(remoteURL, "https://") {
-		parts := strings.Split(remoteURL, "/")
-		if len(parts) >= 5 {
-			owner := parts[len(parts)-2]
-			repo := strings.TrimSuffix(parts[len(parts)-1], ".git")
-			// Validate that owner and repo are not empty
-			if owner != "" && repo != "" {
-				return fmt.Sprintf("%s/%s", owner, repo), nil
-			}
-		}
+	// --watch runs until interrupted
+	if opts.Watch {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+		return app.runTopicUrls(ctx, opts, args)
 	}
 
-	return "", fmt.Errorf("unsupported remote URL format: %s", remoteURL)
+	return app.runTopicUrls(cmd.Context(), opts, args)
 }
 
-func getCurrentRepo(ctx context.Context) (string, error) {
-	cmd := execCommand(ctx, "git", "remote", "get-url", "origin")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to get remote URL: %w", err)
+// runTopicUrls lists the pull requests selected by the root command's options
+func (a *App) runTopicUrls(ctx context.Context, opts rootOptions, args []string) error {
+	if err := validateFormat(a.Format); err != nil {
+		return err
 	}
+	a.Pick = opts.Pick
+	a.Open, a.OpenLimit = opts.Open, opts.OpenLimit
 
-	remoteURL := strings.TrimSpace(string(output))
-	return parseRepoFromURL(remoteURL)
-}
-
-func getCurrentBranch(ctx context.Context) (string, error) {
-	cmd := execCommand(ctx, "git", "branch", "--show-current")
-	output, err := cmd.Output()
+	comment, err := opts.commentOptions()
 	if err != nil {
-		return "", fmt.Errorf("failed to get current branch: %w", err)
+		return err
 	}
+	a.Comment = comment
 
-	branch := strings.TrimSpace(string(output))
-	if branch == "" {
-		return "", fmt.Errorf("could not determine current branch")
+	body, err := opts.bodyOptions()
+	if err != nil {
+		return err
 	}
+	a.UpdateBody = body
 
-	return branch, nil
-}
-
-func branchExists(ctx context.Context, branchName string) (bool, error) {
-	cmd := execCommand(ctx, "git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/heads/%s", branchName))
-	cmd.Stderr = nil // Suppress error output for cleaner check
+	if opts.TopicBranch != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--topic-branch cannot be combined with a branch argument")
+		}
+		args = []string{opts.TopicBranch}
+	}
 
-	err := cmd.Run()
-	if err == nil {
-		return true, nil
+	if opts.Web {
+		if opts.Open || opts.Pick || opts.Auto || opts.FromCommits != "" || len(opts.Repos) > 0 || opts.Org != "" || opts.ScanDir != "" {
+			return fmt.Errorf("--web cannot be combined with --open, --pick, --auto, --from-commits or multi-repository flags")
+		}
 	}
 
-	// Check if it's a remote branch
-	cmd = execCommand(ctx, "git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/remotes/origin/%s", branchName))
-	cmd.Stderr = nil
+	if opts.Auto {
+		if len(args) > 0 || opts.Interactive || opts.FromCommits != "" || len(opts.Repos) > 0 || opts.Org != "" || opts.ScanDir != "" {
+			return fmt.Errorf("--auto cannot be combined with a branch argument, --interactive, --from-commits or multi-repository flags")
+		}
+	}
 
-	err = cmd.Run()
-	return err == nil, nil
-}
+	if opts.Watch {
+		if opts.Pick || opts.Web || opts.Open || opts.Auto || opts.FromCommits != "" || len(opts.Repos) > 0 || opts.Org != "" || opts.ScanDir != "" {
+			return fmt.Errorf("--watch cannot be combined with --pick, --web, --open, --auto, --from-commits or multi-repository flags")
+		}
+		if a.offline() {
+			return fmt.Errorf("--watch cannot be combined with --offline")
+		}
+		if opts.Interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+	} else if opts.Notify {
+		return fmt.Errorf("--notify requires --watch")
+	}
 
-func getAllBranches(ctx context.Context) ([]string, error) {
-	cmd := execCommand(ctx, "git", "branch", "-a", "--sort=-committerdate")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get branches: %w", err)
+	if len(opts.Repos) > 0 || opts.Org != "" || opts.ScanDir != "" {
+		return a.runMultiRepo(ctx, opts, args)
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	branches := make([]string, 0, len(lines))
+	if opts.FromCommits != "" {
+		if opts.Interactive || len(args) > 0 {
+			return fmt.Errorf("--from-commits cannot be combined with a branch argument or --interactive")
+		}
 
-	for _, line := range lines {
-		branch := normalizeBranchName(line)
-		if branch != "" {
-			branches = append(branches, branch)
+		fmt.Fprintf(a.Stderr, "Commit range: %s\n", normalizeCommitRange(opts.FromCommits))
+		if err := a.getCommitRangeUrls(ctx, opts.FromCommits); err != nil {
+			return fmt.Errorf("failed to get pull requests: %w", err)
 		}
+		return nil
 	}
 
-	return branches, nil
-}
+	if opts.Interactive {
+		branches, err := a.selectBranchesInteractively(ctx)
+		if err != nil {
+			return fmt.Errorf("branch selection failed: %w", err)
+		}
+		if len(branches) > 1 && opts.Watch {
+			return fmt.Errorf("--watch follows a single branch; select only one")
+		}
+		if len(branches) > 1 {
+			fmt.Fprintf(a.Stderr, "Selected branches: %s\n", strings.Join(branches, ", "))
+			if opts.Web {
+				for _, branch := range branches {
+					if err := a.openSearchPage(ctx, branch); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if err := a.getBranchesTopicUrls(ctx, branches); err != nil {
+				return fmt.Errorf("failed to get pull requests: %w", err)
+			}
+			return nil
+		}
+		args = branches
+	}
 
-// normalizeBranchName cleans and normalizes a git branch line
-func normalizeBranchName(line string) string {
-	line = strings.TrimSpace(line)
-	if line == "" {
-		return ""
+	var branchName string
+	if (opts.NoVerify || opts.Interactive) && len(args) > 0 {
+		branchName = args[0]
+	} else {
+		branchName, err = a.selectBranchForTopicUrls(ctx, args)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get branch: %w\nUsage: gh-topic-urls [branch-name] or gh-topic-urls -i", err)
 	}
 
-	// Skip HEAD pointer references
-	if strings.Contains(line, "HEAD ->") {
-		return ""
+	if opts.Interactive {
+		fmt.Fprintf(a.Stderr, "Selected branch: %s\n", branchName)
+	} else if len(args) < 1 {
+		fmt.Fprintf(a.Stderr, "Using current branch: %s\n", branchName)
+	} else {
+		fmt.Fprintf(a.Stderr, "Target branch: %s\n", branchName)
 	}
 
-	// Remove current branch indicator (*)
-	if strings.HasPrefix(line, "* ") {
-		line = line[2:]
+	if opts.Web {
+		return a.openSearchPage(ctx, branchName)
 	}
 
-	// Remove origin/ prefix from remote branches
-	if strings.HasPrefix(line, "origin/") {
-		line = line[7:] // len("origin/") = 7
+	if opts.Watch {
+		return a.watchTopicUrls(ctx, branchName, opts.Interval, opts.Notify)
 	}
 
-	return strings.TrimSpace(line)
-}
+	if opts.Auto {
+		pattern, err := a.topicPattern(opts.TopicPattern)
+		if err != nil {
+			return err
+		}
+		if err := a.getAutoTopicUrls(ctx, branchName, pattern); err != nil {
+			return fmt.Errorf("failed to get pull requests: %w", err)
+		}
+		return nil
+	}
 
-// selectBranchInteractively presents an interactive branch selection UI
-func selectBranchInteractively(branches []string) (string, error) {
-	prompt := promptui.Select{
-		Label: "Select branch",
-		Items: branches,
-		Size:  10, // Show up to 10 items at once
-		Templates: &promptui.SelectTemplates{
-			Label:    "{{ . }}:",
-			Active:   "▸ {{ . | cyan | bold }}",
-			Inactive: "  {{ . | faint }}",
-			Selected: "✓ {{ . | green | bold }}",
-		},
+	if err := a.getTopicUrls(ctx, branchName); err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
 	}
 
-	_, selectedBranch, err := prompt.Run()
-	return selectedBranch, err
+	return nil
 }
 
-// selectBranchForTopicUrls handles branch selection logic
-func selectBranchForTopicUrls(ctx context.Context, args []string, interactive bool) (string, error) {
-	if interactive {
-		branches, err := getAllBranches(ctx)
-		if err != nil {
-			return "", err
+// commentOptions validates the comment flags and returns them for the App
+func (opts rootOptions) commentOptions() (commentOptions, error) {
+	switch {
+	case opts.CommentOn < 0 || opts.CommentOnIssue < 0:
+		return commentOptions{}, fmt.Errorf("--comment-on and --comment-on-issue take a positive number")
+	case opts.CommentOn > 0 && opts.CommentOnIssue > 0:
+		return commentOptions{}, fmt.Errorf("--comment-on cannot be combined with --comment-on-issue")
+	case opts.CommentOn == 0 && opts.CommentOnIssue == 0:
+		if opts.UpdateComment {
+			return commentOptions{}, fmt.Errorf("--update-comment requires --comment-on or --comment-on-issue")
 		}
+		return commentOptions{}, nil
+	case opts.Web || opts.Watch:
+		return commentOptions{}, fmt.Errorf("--comment-on and --comment-on-issue cannot be combined with --web or --watch")
+	case opts.CommentOn > 0:
+		return commentOptions{Number: opts.CommentOn, Update: opts.UpdateComment}, nil
+	default:
+		return commentOptions{Number: opts.CommentOnIssue, Issue: true, Update: opts.UpdateComment}, nil
+	}
+}
 
-		if len(branches) == 0 {
-			return "", fmt.Errorf("no branches found")
+// bodyOptions validates the description update flags and returns them for the App
+func (opts rootOptions) bodyOptions() (bodyOptions, error) {
+	switch {
+	case opts.UpdateBody < 0:
+		return bodyOptions{}, fmt.Errorf("--update-body takes a positive pull request number")
+	case opts.UpdateBody == 0:
+		if opts.DryRun {
+			return bodyOptions{}, fmt.Errorf("--dry-run requires --update-body")
 		}
+		return bodyOptions{}, nil
+	case opts.Web || opts.Watch:
+		return bodyOptions{}, fmt.Errorf("--update-body cannot be combined with --web or --watch")
+	default:
+		return bodyOptions{Number: opts.UpdateBody, DryRun: opts.DryRun}, nil
+	}
+}
 
-		selectedBranch, err := selectBranchInteractively(branches)
-		if err != nil {
-			return "", fmt.Errorf("branch selection cancelled: %w", err)
-		}
+func Execute() {
+	app := newApp()
+	if err := newRootCmd().ExecuteContext(withApp(context.Background(), app)); err != nil {
+		fmt.Fprintf(app.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
 
-		return selectedBranch, nil
+// selectBranchForTopicUrls returns the branch argument after checking that it
+// exists, or the current branch when there is no argument
+func (a *App) selectBranchForTopicUrls(ctx context.Context, args []string) (string, error) {
+	repo, err := a.openGitRepo("")
+	if err != nil {
+		return "", err
 	}
 
-	// Non-interactive mode
-	if len(args) < 1 {
-		return getCurrentBranch(ctx)
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
 	}
+	branch, err := topicurls.ResolveBranch(ctx, repo, branchName, a.Getenv)
 
-	branchName := args[0]
-	exists, err := branchExists(ctx, branchName)
-	if err != nil {
-		return "", fmt.Errorf("failed to check branch existence: %w", err)
+	// The branch may exist on GitHub without having been fetched
+	var notFound *topicurls.BranchNotFoundError
+	if errors.As(err, &notFound) && a.branchOnGitHub(ctx, repo, branchName) {
+		return branchName, nil
 	}
-	if !exists {
-		return "", fmt.Errorf("branch '%s' does not exist", branchName)
+	return branch, err
+}
+
+// branchOnGitHub reports whether branch exists in the GitHub repository of the
+// checkout's origin remote; lookup failures count as missing
+func (a *App) branchOnGitHub(ctx context.Context, repo topicurls.GitRepo, branch string) bool {
+	repoName, err := topicurls.RepoFromRemote(ctx, repo, "origin")
+	if err != nil {
+		return false
 	}
 
-	return branchName, nil
+	exists, err := a.client().BranchExists(ctx, repoName, branch)
+	return err == nil && exists
 }
 
 // branchCompletion provides branch name completions for shell auto-completion
@@ -246,10 +362,11 @@ func branchCompletion(cmd *cobra.Command, args []string, toComplete string) ([]s
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	app := appFrom(cmd)
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
 	defer cancel()
 
-	branches, err := getAllBranches(ctx)
+	branches, err := app.getAllBranches(ctx)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveError
 	}
@@ -257,70 +374,99 @@ func branchCompletion(cmd *cobra.Command, args []string, toComplete string) ([]s
 	// Filter branches based on what the user has typed so far
 	var filteredBranches []string
 	for _, branch := range branches {
-		if strings.HasPrefix(branch, toComplete) {
-			filteredBranches = append(filteredBranches, branch)
+		if strings.HasPrefix(branch.Name, toComplete) {
+			filteredBranches = append(filteredBranches, cobra.CompletionWithDesc(branch.Name, describeBranch(branch)))
 		}
 	}
 
 	return filteredBranches, cobra.ShellCompDirectiveNoFileComp
 }
 
-func getTopicUrls(ctx context.Context, branchName string) error {
-	repo, err := getCurrentRepo(ctx)
+// describeBranch summarizes where a branch lives and its last commit for completion descriptions
+func describeBranch(branch topicurls.Branch) string {
+	var parts []string
+	if branch.Remote != "" {
+		parts = append(parts, branch.Remote)
+	}
+	if branch.Author != "" {
+		parts = append(parts, branch.Author)
+	}
+	if !branch.CommitDate.IsZero() {
+		parts = append(parts, branch.CommitDate.Format("2006-01-02"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (a *App) getTopicUrls(ctx context.Context, branchName string) error {
+	repo, err := a.getCurrentRepo(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get current repository: %w", err)
 	}
 
-	apiURL := fmt.Sprintf("/repos/%s/pulls?state=all&base=%s&sort=created-asc", repo, branchName)
+	prs, err := a.fetchPullRequests(ctx, repo, branchName)
+	if err != nil {
+		return err
+	}
 
-	ghCmd := exec.CommandContext(ctx, "gh", "api",
-		"-H", "Accept: application/vnd.github+json",
-		"-H", "X-GitHub-Api-Version: 2022-11-28",
-		apiURL,
-	)
+	if len(prs) == 0 {
+		fmt.Fprintf(a.Stderr, "No pull requests found for branch '%s'\n", branchName)
+		return nil
+	}
 
-	jqCmd := exec.CommandContext(ctx, "jq", "-r", `"- " + .[].html_url`)
+	return a.outputPullRequests(ctx, repo, prs)
+}
 
-	jqStdin, err := jqCmd.StdinPipe()
+// outputPullRequests prints the pull request list, narrowed with --pick, and
+// copies it to the clipboard
+func (a *App) outputPullRequests(ctx context.Context, repo string, prs []pullRequest) error {
+	prs, err := a.pickPullRequests(prs)
 	if err != nil {
-		return fmt.Errorf("jq stdin pipe error: %w", err)
+		return err
+	}
+	if len(prs) == 0 {
+		fmt.Fprintln(a.Stderr, "No pull requests selected")
+		return nil
 	}
 
-	ghCmd.Stdout = jqStdin
-	ghCmd.Stderr = os.Stderr
+	section := reportSection{PullRequests: prs}
+	if a.WithIssues {
+		section.Issues = a.collectResolvedIssues(ctx, repo, prs)
+	}
 
-	var jqOutput bytes.Buffer
-	jqCmd.Stdout = &jqOutput
-	jqCmd.Stderr = os.Stderr
+	return a.outputReport(ctx, report{Sections: []reportSection{section}})
+}
 
-	if err := jqCmd.Start(); err != nil {
-		return fmt.Errorf("jq start error: %w", err)
+// outputReport renders a report in the selected format, prints it, copies it
+// to the clipboard, posts it with --comment-on and --update-body and opens its
+// pull requests with --open
+func (a *App) outputReport(ctx context.Context, r report) error {
+	urls, err := renderReport(a.Format, r)
+	if err != nil {
+		return err
 	}
 
-	if err := ghCmd.Run(); err != nil {
-		jqStdin.Close()
-		return fmt.Errorf("gh api error: %w", err)
+	fmt.Fprint(a.Stdout, urls)
+
+	if err := a.Clipboard(urls); err != nil {
+		return fmt.Errorf("clipboard copy error: %w", err)
 	}
 
-	jqStdin.Close()
+	fmt.Fprintln(a.Stderr, "✨ Copied to clipboard")
 
-	if err := jqCmd.Wait(); err != nil {
-		return fmt.Errorf("jq wait error: %w", err)
+	if a.Comment.Number > 0 {
+		if err := a.postComment(ctx, urls); err != nil {
+			return err
+		}
 	}
 
-	urls := jqOutput.String()
-	urlsTrimmed := strings.TrimSpace(urls)
-	if urlsTrimmed == "" {
-		fmt.Printf("No pull requests found for branch '%s'\n", branchName)
-		return nil
+	if a.UpdateBody.Number > 0 {
+		if err := a.updatePullRequestBody(ctx, urls); err != nil {
+			return err
+		}
 	}
 
-	fmt.Print(urls)
-
-	if err := clipboard.WriteAll(urls); err != nil {
-		return fmt.Errorf("clipboard copy error: %w", err)
+	if a.Open {
+		return a.openPullRequests(r)
 	}
-
-	fmt.Println("✨ Copied to clipboard")
 	return nil
 }
@@ -3,95 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os/exec"
+	"strings"
 	"testing"
 
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestParseRepoFromURL(t *testing.T) {
-	tests := []struct {
-		name         string
-		remoteURL    string
-		expectedRepo string
-		expectError  bool
-	}{
-		{
-			name:         "SSH URL with .git",
-			remoteURL:    "git@github.com:owner/repo.git",
-			expectedRepo: "owner/repo",
-		},
-		{
-			name:         "SSH URL without .git",
-			remoteURL:    "git@github.com:owner/repo",
-			expectedRepo: "owner/repo",
-		},
-		{
-			name:         "HTTPS URL with .git",
-			remoteURL:    "https://github.com/owner/repo.git",
-			expectedRepo: "owner/repo",
-		},
-		{
-			name:         "HTTPS URL without .git",
-			remoteURL:    "https://github.com/owner/repo",
-			expectedRepo: "owner/repo",
-		},
-		{
-			name:         "SSH URL with nested path",
-			remoteURL:    "git@github.com:organization/project-name.git",
-			expectedRepo: "organization/project-name",
-		},
-		{
-			name:         "HTTPS URL with nested path",
-			remoteURL:    "https://github.com/my-org/my-awesome-project.git",
-			expectedRepo: "my-org/my-awesome-project",
-		},
-		{
-			name:        "Unsupported URL format",
-			remoteURL:   "ftp://example.com/repo.git",
-			expectError: true,
-		},
-		{
-			name:        "Empty URL",
-			remoteURL:   "",
-			expectError: true,
-		},
-		{
-			name:        "Invalid SSH format",
-			remoteURL:   "git@github.com",
-			expectError: true,
-		},
-		{
-			name:        "Invalid HTTPS format",
-			remoteURL:   "https://github.com/",
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Given: Remote URL
-			remoteURL := tt.remoteURL
-
-			// When: Parse URL
-			result, err := parseRepoFromURL(remoteURL)
-
-			// Then: Verify results
-			if tt.expectError {
-				assert.Error(t, err)
-				assert.Empty(t, result)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedRepo, result)
-			}
-		})
-	}
-}
-
-// Store original execCommand for restoration
-var originalExecCommand = execCommand
-
 // mockExecCommand creates a mock command that returns specified output or error
 func mockExecCommand(mockOutput string, mockError error) func(context.Context, string, ...string) *exec.Cmd {
 	return func(ctx context.Context, name string, args ...string) *exec.Cmd {
@@ -105,6 +27,8 @@ func mockExecCommand(mockOutput string, mockError error) func(context.Context, s
 }
 
 func TestGetCurrentRepo(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name        string
 		mockOutput  string
@@ -141,13 +65,14 @@ func TestGetCurrentRepo(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			// Arrange: Setup mock command execution
-			execCommand = mockExecCommand(tt.mockOutput, tt.mockError)
-			defer func() { execCommand = originalExecCommand }()
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
 
 			// Act: Execute getCurrentRepo
 			ctx := context.Background()
-			result, err := getCurrentRepo(ctx)
+			result, err := app.getCurrentRepo(ctx)
 
 			// Assert: Verify results
 			if tt.expectError {
@@ -162,6 +87,8 @@ func TestGetCurrentRepo(t *testing.T) {
 }
 
 func TestGetCurrentBranch(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name        string
 		mockOutput  string
@@ -208,13 +135,14 @@ func TestGetCurrentBranch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			// Arrange: Setup mock command execution
-			execCommand = mockExecCommand(tt.mockOutput, tt.mockError)
-			defer func() { execCommand = originalExecCommand }()
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
 
 			// Act: Execute getCurrentBranch
 			ctx := context.Background()
-			result, err := getCurrentBranch(ctx)
+			result, err := app.getCurrentBranch(ctx)
 
 			// Assert: Verify results
 			if tt.expectError {
@@ -229,6 +157,8 @@ func TestGetCurrentBranch(t *testing.T) {
 }
 
 func TestBranchExists(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name        string
 		branchName  string
@@ -256,13 +186,14 @@ func TestBranchExists(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			// Arrange: Setup mock command execution
-			execCommand = mockExecCommand("", tt.mockError)
-			defer func() { execCommand = originalExecCommand }()
+			app, _ := newTestApp(mockExecCommand("", tt.mockError))
 
 			// Act: Execute branchExists
 			ctx := context.Background()
-			result, err := branchExists(ctx, tt.branchName)
+			result, err := app.branchExists(ctx, tt.branchName)
 
 			// Assert: Verify results
 			if tt.expectError {
@@ -275,7 +206,25 @@ func TestBranchExists(t *testing.T) {
 	}
 }
 
+// refLine formats a git for-each-ref branch record as printed by the exec backend
+func refLine(ref, upstream, date, author string) string {
+	return strings.Join([]string{ref, upstream, date, author}, "\t")
+}
+
+// branchRefs is for-each-ref output with local, origin and upstream branches
+var branchRefs = strings.Join([]string{
+	refLine("refs/heads/main", "origin/main", "2025-09-05T10:00:00Z", "Alice"),
+	refLine("refs/remotes/origin/main", "", "2025-09-05T10:00:00Z", "Alice"),
+	refLine("refs/remotes/origin/HEAD", "", "2025-09-05T10:00:00Z", "Alice"),
+	refLine("refs/heads/feature/test-branch", "", "2025-09-04T10:00:00Z", "Bob"),
+	refLine("refs/remotes/origin/develop", "", "2025-09-03T10:00:00Z", "Carol"),
+	refLine("refs/remotes/upstream/develop", "", "2025-09-03T10:00:00Z", "Carol"),
+	refLine("refs/remotes/upstream/feature/another-branch", "", "2025-09-02T10:00:00Z", "Dave"),
+}, "\n")
+
 func TestGetAllBranches(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name        string
 		mockOutput  string
@@ -284,26 +233,15 @@ func TestGetAllBranches(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Multiple branches with origin prefix",
-			mockOutput: `  main
-  feature/test-feature
-  origin/develop
-  origin/feature/remote-only`,
-			expected: []string{"main", "feature/test-feature", "develop", "feature/remote-only"},
+			name:       "Local and origin branches without prefixes or duplicates",
+			mockOutput: branchRefs,
+			expected:   []string{"main", "feature/test-branch", "develop"},
 		},
 		{
 			name:       "Single branch",
-			mockOutput: `  main`,
+			mockOutput: refLine("refs/heads/main", "", "2025-09-05T10:00:00Z", "Alice"),
 			expected:   []string{"main"},
 		},
-		{
-			name: "Mixed local and remote branches",
-			mockOutput: `  main
-  develop
-  origin/feature/branch1
-  origin/hotfix/urgent-fix`,
-			expected: []string{"main", "develop", "feature/branch1", "hotfix/urgent-fix"},
-		},
 		{
 			name:        "Git command error",
 			mockError:   fmt.Errorf("git command failed"),
@@ -318,13 +256,14 @@ func TestGetAllBranches(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			// Arrange: Setup mock command execution
-			execCommand = mockExecCommand(tt.mockOutput, tt.mockError)
-			defer func() { execCommand = originalExecCommand }()
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
 
-			// Act: Execute getAllBranches (this function doesn't exist yet - TDD Red phase)
+			// Act: List the branches
 			ctx := context.Background()
-			result, err := getAllBranches(ctx)
+			result, err := app.getAllBranches(ctx)
 
 			// Assert: Verify results
 			if tt.expectError {
@@ -332,71 +271,49 @@ func TestGetAllBranches(t *testing.T) {
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, tt.expected, topicurls.BranchNames(result))
 			}
 		})
 	}
 }
 
 func TestSelectBranchForTopicUrls(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name               string
 		args               []string
-		interactiveMode    bool
 		mockBranchesOutput string
 		mockBranchesError  error
 		expectError        bool
 	}{
 		{
-			name:               "Non-interactive with no args uses current branch",
+			name:               "No args uses current branch",
 			args:               []string{},
-			interactiveMode:    false,
 			mockBranchesOutput: "main",
 		},
 		{
-			name:               "Non-interactive with branch argument",
+			name:               "Branch argument",
 			args:               []string{"develop"},
-			interactiveMode:    false,
 			mockBranchesOutput: "develop",
 		},
 		{
-			name:              "Non-interactive with non-existent branch",
+			name:              "Non-existent branch",
 			args:              []string{"nonexistent"},
-			interactiveMode:   false,
 			mockBranchesError: fmt.Errorf("branch not found"),
 			expectError:       true,
 		},
-		{
-			name:              "Interactive mode with git error",
-			args:              []string{},
-			interactiveMode:   true,
-			mockBranchesError: fmt.Errorf("git command failed"),
-			expectError:       true,
-		},
-		{
-			name:               "Interactive mode with no branches",
-			args:               []string{},
-			interactiveMode:    true,
-			mockBranchesOutput: "",
-			expectError:        true,
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Skip interactive UI tests for now as they require user input
-			if tt.interactiveMode && !tt.expectError {
-				t.Skip("Interactive mode with user input not testable in unit tests")
-				return
-			}
+			t.Parallel()
 
-			// Setup: Mock execCommand
-			originalExecCommand := execCommand
-			execCommand = mockExecCommand(tt.mockBranchesOutput, tt.mockBranchesError)
-			defer func() { execCommand = originalExecCommand }()
+			// Setup: Mock command execution
+			app, _ := newTestApp(mockExecCommand(tt.mockBranchesOutput, tt.mockBranchesError))
 
 			// Act: Call selectBranchForTopicUrls
-			_, err := selectBranchForTopicUrls(context.Background(), tt.args, tt.interactiveMode)
+			_, err := app.selectBranchForTopicUrls(context.Background(), tt.args)
 
 			// Assert: Verify behavior
 			if tt.expectError {
@@ -408,7 +325,66 @@ func TestSelectBranchForTopicUrls(t *testing.T) {
 	}
 }
 
+func TestSelectBranchVerifiesOnGitHub(t *testing.T) {
+	t.Parallel()
+
+	forEachRef := "for-each-ref --sort=-committerdate --format=%(refname)%09%(upstream:short)%09%(committerdate:iso-strict)%09%(authorname) refs/heads refs/remotes"
+
+	tests := []struct {
+		name          string
+		apiStatus     int
+		expectedError string
+	}{
+		{name: "Branch only on GitHub", apiStatus: http.StatusOK},
+		{name: "Missing everywhere", apiStatus: http.StatusNotFound, expectedError: "branch 'release/nxet' does not exist; did you mean release/next?"},
+		{name: "Lookup failure", apiStatus: http.StatusBadGateway, expectedError: "branch 'release/nxet' does not exist; did you mean release/next?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: release/nxet is in neither refs/heads nor refs/remotes/origin
+			app, _ := newTestApp(mockGitCommands(map[string]string{
+				"remote get-url origin": "git@github.com:owner/repo.git",
+				forEachRef:              refLine("refs/remotes/origin/release/next", "", "2025-09-05T10:00:00Z", "Alice"),
+			}))
+			api := (&fakeAPI{}).on("/repos/owner/repo/branches/release%2Fnxet", &apiResponse{StatusCode: tt.apiStatus, Body: []byte(`{}`)})
+			app.API = api
+
+			// Act: Select the branch given as argument
+			branch, err := app.selectBranchForTopicUrls(context.Background(), []string{"release/nxet"})
+
+			// Assert: The branches API decides, and misses suggest similar branches
+			assert.Len(t, api.paths(), 1)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "release/nxet", branch)
+		})
+	}
+}
+
+func TestNoVerifySkipsBranchChecks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Only the remote and the pull requests are available
+	app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+	app.API = newFakeAPI(map[string]string{"base=release%2Funfetched": `[]`})
+
+	// Act: Query a branch that git does not know
+	err := app.runTopicUrls(context.Background(), rootOptions{NoVerify: true}, []string{"release/unfetched"})
+
+	// Assert: The pull requests are fetched without validating the branch
+	assert.NoError(t, err)
+	assert.Equal(t, "Target branch: release/unfetched\nNo pull requests found for branch 'release/unfetched'\n", out.String())
+}
+
 func TestBranchCompletion(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
 		name               string
 		args               []string
@@ -419,32 +395,29 @@ func TestBranchCompletion(t *testing.T) {
 		expectError        bool
 	}{
 		{
-			name:       "Complete all branches with empty input",
-			args:       []string{},
-			toComplete: "",
-			mockBranchesOutput: `  main
-  feature/test-branch
-  origin/develop`,
-			expectedBranches: []string{"main", "feature/test-branch", "develop"},
+			name:               "Complete all branches with empty input",
+			args:               []string{},
+			toComplete:         "",
+			mockBranchesOutput: branchRefs,
+			expectedBranches: []string{
+				"main\tAlice, 2025-09-05",
+				"feature/test-branch\tBob, 2025-09-04",
+				"develop\torigin, Carol, 2025-09-03",
+			},
 		},
 		{
-			name:       "Complete branches starting with 'ma'",
-			args:       []string{},
-			toComplete: "ma",
-			mockBranchesOutput: `  main
-  feature/test-branch
-  origin/develop`,
-			expectedBranches: []string{"main"},
+			name:               "Complete branches starting with 'ma'",
+			args:               []string{},
+			toComplete:         "ma",
+			mockBranchesOutput: branchRefs,
+			expectedBranches:   []string{"main\tAlice, 2025-09-05"},
 		},
 		{
-			name:       "Complete branches starting with 'feature/'",
-			args:       []string{},
-			toComplete: "feature/",
-			mockBranchesOutput: `  main
-  feature/test-branch
-  feature/another-branch
-  origin/develop`,
-			expectedBranches: []string{"feature/test-branch", "feature/another-branch"},
+			name:               "Complete branches starting with 'feature/'",
+			args:               []string{},
+			toComplete:         "feature/",
+			mockBranchesOutput: branchRefs,
+			expectedBranches:   []string{"feature/test-branch\tBob, 2025-09-04"},
 		},
 		{
 			name:             "No completion for second argument",
@@ -463,13 +436,15 @@ func TestBranchCompletion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Setup: Mock execCommand
-			originalExecCommand := execCommand
-			execCommand = mockExecCommand(tt.mockBranchesOutput, tt.mockBranchesError)
-			defer func() { execCommand = originalExecCommand }()
+			t.Parallel()
+
+			// Setup: Mock command execution
+			app, _ := newTestApp(mockExecCommand(tt.mockBranchesOutput, tt.mockBranchesError))
+			cmd := &cobra.Command{}
+			cmd.SetContext(withApp(context.Background(), app))
 
 			// Act: Call branchCompletion
-			branches, directive := branchCompletion(nil, tt.args, tt.toComplete)
+			branches, directive := branchCompletion(cmd, tt.args, tt.toComplete)
 
 			// Assert: Verify results
 			if tt.expectError {
@@ -482,3 +457,23 @@ func TestBranchCompletion(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRootCmdStartsFromDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Parse flags into a first command tree
+	first := newRootCmd()
+	require.NoError(t, first.ParseFlags([]string{"--format", "json", "--no-verify", "--repos", "org/api"}))
+
+	// Act: Build a second tree
+	second := newRootCmd()
+
+	// Assert: The second tree does not see the flags of the first
+	for _, name := range []string{"format", "no-verify", "repos"} {
+		flag := second.Flag(name)
+		require.NotNil(t, flag, name)
+		assert.Equal(t, flag.DefValue, flag.Value.String(), name)
+		assert.False(t, flag.Changed, name)
+	}
+	assert.Equal(t, "json", first.Flag("format").Value.String())
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxScanDepth limits how deep --scan-dir looks for checkouts below the given directory
+const maxScanDepth = 3
+
+// checkout is a local git working tree and the GitHub repository of its origin remote
+type checkout struct {
+	Dir  string
+	Repo string
+}
+
+// findGitCheckouts returns the directories under root that contain a .git entry.
+// Checkouts are not descended into, and hidden directories are skipped.
+func findGitCheckouts(root string) ([]string, error) {
+	root = filepath.Clean(root)
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped instead of aborting the scan
+			if path != root && d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		// .git is a directory in clones and a file in linked worktrees and submodules
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			dirs = append(dirs, path)
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= maxScanDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	return dirs, nil
+}
+
+// expandHome replaces a leading "~" with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// scanCheckoutsWithBranch finds the checkouts under dir that have branchName
+// locally or on origin and returns their repositories without duplicates
+func (a *App) scanCheckoutsWithBranch(ctx context.Context, dir, branchName string) ([]string, error) {
+	dirs, err := findGitCheckouts(expandHome(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	var checkouts []checkout
+	for _, d := range dirs {
+		repo, err := a.getRepoInDir(ctx, d)
+		if err != nil {
+			fmt.Fprintf(a.Stderr, "⚠ skipping %s: %v\n", d, err)
+			continue
+		}
+
+		exists, err := a.branchExistsInDir(ctx, d, branchName)
+		if err != nil || !exists {
+			continue
+		}
+		checkouts = append(checkouts, checkout{Dir: d, Repo: repo})
+	}
+
+	fmt.Fprintf(a.Stderr, "Scanned %s: %d checkouts, %d with branch '%s'\n", dir, len(dirs), len(checkouts), branchName)
+
+	repos := make([]string, 0, len(checkouts))
+	for _, c := range checkouts {
+		repos = append(repos, c.Repo)
+	}
+	return parseRepoList(repos)
+}
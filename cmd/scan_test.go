@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeCheckout creates a directory containing an empty .git directory
+func makeCheckout(t *testing.T, path string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Join(path, ".git"), 0o755))
+}
+
+func TestFindGitCheckouts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A source tree with checkouts at several depths
+	root := t.TempDir()
+	makeCheckout(t, filepath.Join(root, "api"))
+	makeCheckout(t, filepath.Join(root, "api", "vendor", "nested")) // inside a checkout
+	makeCheckout(t, filepath.Join(root, "team", "web"))
+	makeCheckout(t, filepath.Join(root, ".cache", "hidden"))
+	makeCheckout(t, filepath.Join(root, "a", "b", "c", "too-deep"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "notes"), 0o755))
+
+	// Linked worktrees use a .git file instead of a directory
+	worktree := filepath.Join(root, "worktree")
+	assert.NoError(t, os.MkdirAll(worktree, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: ../api/.git/worktrees/x\n"), 0o644))
+
+	// Act: Find checkouts
+	dirs, err := findGitCheckouts(root)
+
+	// Assert: Only top-level, visible checkouts within the depth limit are found
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, "api"),
+		filepath.Join(root, "team", "web"),
+		worktree,
+	}, dirs)
+}
+
+func TestFindGitCheckoutsMissingDir(t *testing.T) {
+	t.Parallel()
+
+	_, err := findGitCheckouts(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestScanCheckoutsWithBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		mockOutput string
+		mockError  error
+		expected   []string
+	}{
+		{
+			name:       "Clones of the same repository are deduplicated",
+			mockOutput: "git@github.com:org/api.git",
+			expected:   []string{"org/api"},
+		},
+		{
+			name:      "Checkouts without a usable remote are skipped",
+			mockError: fmt.Errorf("no origin"),
+			expected:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Two checkouts and mock git commands
+			root := t.TempDir()
+			makeCheckout(t, filepath.Join(root, "api"))
+			makeCheckout(t, filepath.Join(root, "api-copy"))
+
+			app, _ := newTestApp(mockExecCommand(tt.mockOutput, tt.mockError))
+
+			// Act: Scan for the branch
+			repos, err := app.scanCheckoutsWithBranch(context.Background(), root, "feature/payments-v2")
+
+			// Assert: Verify repositories
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, repos)
+		})
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	t.Parallel()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("home directory not available")
+	}
+
+	assert.Equal(t, filepath.Join(home, "src", "org"), expandHome("~/src/org"))
+	assert.Equal(t, home, expandHome("~"))
+	assert.Equal(t, "/src/org", expandHome("/src/org"))
+	assert.Equal(t, "~other/src", expandHome("~other/src"))
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// snapshotDirEnv overrides the directory snapshots are stored in, e.g. to share them in a repository
+const snapshotDirEnv = "GH_TOPIC_URLS_SNAPSHOT_DIR"
+
+// snapshotNamePattern restricts snapshot names to safe file names
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// snapshot is the pull request list of a branch saved by "snapshot save"
+type snapshot struct {
+	Name         string                `json:"name"`
+	Repo         string                `json:"repo"`
+	Branch       string                `json:"branch"`
+	SavedAt      time.Time             `json:"saved_at"`
+	PullRequests []snapshotPullRequest `json:"pull_requests"`
+}
+
+// snapshotPullRequest is the part of a pull request kept in a snapshot
+type snapshotPullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	URL      string     `json:"url"`
+	State    string     `json:"state"`
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+}
+
+// newSnapshotCmd builds the snapshot command and its subcommands
+func newSnapshotCmd() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save the pull request list of a branch and compare it later",
+		Long: `Save the pull requests into a branch under a name, then list what was added,
+removed, merged, closed or reopened since.
+
+Snapshots are JSON files stored per repository in the user configuration
+directory, or under $` + snapshotDirEnv + ` when set.`,
+	}
+	snapshotCmd.AddCommand(newSnapshotSaveCmd(), newSnapshotDiffCmd())
+	return snapshotCmd
+}
+
+func newSnapshotSaveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "save <name> [branch-name]",
+		Short: "Save the pull requests into a branch, the current one by default",
+		Example: `  gh topic-urls snapshot save qa-pass-3 release/next
+  gh topic-urls snapshot save before-freeze`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runSnapshotSave,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return branchCompletion(cmd, args[1:], toComplete)
+		},
+	}
+}
+
+func newSnapshotDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "diff <name>",
+		Short:             "List the pull request changes on the snapshot's branch since it was saved",
+		Example:           `  gh topic-urls snapshot diff qa-pass-3`,
+		Args:              cobra.ExactArgs(1),
+		RunE:              runSnapshotDiff,
+		ValidArgsFunction: cobra.NoFileCompletions,
+	}
+}
+
+func runSnapshotSave(cmd *cobra.Command, args []string) error {
+	return appFrom(cmd).saveSnapshot(cmd.Context(), args[0], args[1:])
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) error {
+	return appFrom(cmd).diffSnapshot(cmd.Context(), args[0])
+}
+
+// saveSnapshot stores the pull requests into the branch argument, or the current branch, as snapshot name
+func (a *App) saveSnapshot(ctx context.Context, name string, args []string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+
+	branch, err := a.selectBranchForTopicUrls(ctx, args)
+	if err != nil {
+		return fmt.Errorf("failed to get branch: %w", err)
+	}
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	prs, err := a.fetchPullRequests(ctx, repo, branch)
+	if err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+
+	snap := snapshot{Name: name, Repo: repo, Branch: branch, SavedAt: a.Now().UTC(), PullRequests: []snapshotPullRequest{}}
+	for _, pr := range prs {
+		snap.PullRequests = append(snap.PullRequests, snapshotPullRequest{
+			Number:   pr.Number,
+			Title:    pr.Title,
+			URL:      pr.HTMLURL,
+			State:    pr.State,
+			MergedAt: pr.MergedAt,
+		})
+	}
+
+	path, err := a.snapshotPath(repo, name)
+	if err != nil {
+		return err
+	}
+	if err := writeSnapshot(path, snap); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.Stdout, "✨ Saved %s into '%s' as snapshot '%s' (%s)\n", countPullRequests(len(prs)), branch, name, path)
+	return nil
+}
+
+// diffSnapshot prints the changes between snapshot name and the current pull requests of its branch
+func (a *App) diffSnapshot(ctx context.Context, name string) error {
+	if err := validateSnapshotName(name); err != nil {
+		return err
+	}
+
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	path, err := a.snapshotPath(repo, name)
+	if err != nil {
+		return err
+	}
+	snap, err := readSnapshot(path)
+	if err != nil {
+		return err
+	}
+
+	prs, err := a.fetchPullRequests(ctx, repo, snap.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+
+	fmt.Fprintf(a.Stdout, "Changes to '%s' since snapshot '%s' (%s)\n", snap.Branch, name, snap.SavedAt.Local().Format("2006-01-02 15:04"))
+
+	changes := diffPullRequests(snap.pullRequests(), prs)
+	if len(changes) == 0 {
+		fmt.Fprintln(a.Stdout, "No changes")
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Fprintf(a.Stdout, "%s\n", change)
+	}
+	fmt.Fprintf(a.Stdout, "%s\n", describeChanges(changes))
+	return nil
+}
+
+// pullRequests returns the saved pull requests in the form returned by the API
+func (s snapshot) pullRequests() []pullRequest {
+	prs := make([]pullRequest, 0, len(s.PullRequests))
+	for _, pr := range s.PullRequests {
+		prs = append(prs, pullRequest{Number: pr.Number, Title: pr.Title, HTMLURL: pr.URL, State: pr.State, MergedAt: pr.MergedAt})
+	}
+	return prs
+}
+
+// validateSnapshotName rejects names that are not plain file names
+func validateSnapshotName(name string) error {
+	if !snapshotNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name '%s': use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// snapshotPath returns the file of snapshot name for repo
+func (a *App) snapshotPath(repo, name string) (string, error) {
+	dir := a.Getenv(snapshotDirEnv)
+	if dir == "" {
+		config, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate snapshot directory: %w", err)
+		}
+		dir = filepath.Join(config, cacheDirName, "snapshots")
+	}
+	return filepath.Join(dir, filepath.FromSlash(repo), name+".json"), nil
+}
+
+// writeSnapshot stores a snapshot as indented JSON, replacing any previous one
+func writeSnapshot(path string, snap snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// readSnapshot loads a snapshot saved by writeSnapshot
+func readSnapshot(path string) (snapshot, error) {
+	var snap snapshot
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return snap, fmt.Errorf("snapshot %s does not exist (save it first with 'gh topic-urls snapshot save')", path)
+		}
+		return snap, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	return snap, nil
+}
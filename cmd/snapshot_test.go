@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSnapshotApp returns a test App on release/next of owner/repo that stores snapshots in dir
+func newSnapshotApp(dir string, routes map[string]string) (*App, func() string) {
+	app, out := newTestApp(mockGitCommands(defaultGitOutputs))
+	app.API = newFakeAPI(routes)
+	app.Getenv = func(key string) string {
+		if key == snapshotDirEnv {
+			return dir
+		}
+		return ""
+	}
+	return app, out.String
+}
+
+func TestSnapshotSaveAndDiff(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "owner", "repo", "qa-pass-3.json")
+
+	// Arrange & Act: Save two open pull requests into release/next
+	app, out := newSnapshotApp(dir, map[string]string{"base=release%2Fnext": `[
+		{"number":7,"title":"Add login","state":"open","html_url":"https://github.com/owner/repo/pull/7"},
+		{"number":12,"title":"Fix typo","state":"open","html_url":"https://github.com/owner/repo/pull/12"}]`})
+	require.NoError(t, app.saveSnapshot(context.Background(), "qa-pass-3", []string{"release/next"}))
+
+	// Assert: The snapshot is stored as JSON per repository
+	assert.Equal(t, "✨ Saved 2 pull requests into 'release/next' as snapshot 'qa-pass-3' ("+path+")\n", out())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "qa-pass-3",
+		"repo": "owner/repo",
+		"branch": "release/next",
+		"saved_at": "2025-09-06T12:00:00Z",
+		"pull_requests": [
+			{"number": 7, "title": "Add login", "url": "https://github.com/owner/repo/pull/7", "state": "open"},
+			{"number": 12, "title": "Fix typo", "url": "https://github.com/owner/repo/pull/12", "state": "open"}
+		]
+	}`, string(data))
+
+	// Act: #7 is merged, #12 retargeted and #15 opened since
+	app, out = newSnapshotApp(dir, map[string]string{"base=release%2Fnext": `[
+		{"number":7,"title":"Add login","state":"closed","merged_at":"2025-09-06T09:00:00Z","html_url":"https://github.com/owner/repo/pull/7"},
+		{"number":15,"title":"Drop legacy API","state":"open","html_url":"https://github.com/owner/repo/pull/15"}]`})
+	require.NoError(t, app.diffSnapshot(context.Background(), "qa-pass-3"))
+
+	// Assert: Every change since the snapshot
+	assert.Contains(t, out(), "Changes to 'release/next' since snapshot 'qa-pass-3' (")
+	assert.Contains(t, out(), ")\n✔ #7 Add login (merged)\n+ #15 Drop legacy API (added)\n- #12 Fix typo (removed)\n1 added, 1 merged, 1 removed\n")
+}
+
+func TestSnapshotDiffWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	routes := map[string]string{"base=release%2Fnext": `[{"number":7,"title":"Add login","state":"open","html_url":"https://github.com/owner/repo/pull/7"}]`}
+
+	app, _ := newSnapshotApp(dir, routes)
+	require.NoError(t, app.saveSnapshot(context.Background(), "nightly", nil))
+
+	app, out := newSnapshotApp(dir, routes)
+	require.NoError(t, app.diffSnapshot(context.Background(), "nightly"))
+
+	assert.Contains(t, out(), "No changes\n")
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		run           func(app *App) error
+		expectedError string
+	}{
+		{
+			name:          "Name with a path separator",
+			run:           func(app *App) error { return app.saveSnapshot(context.Background(), "../qa", nil) },
+			expectedError: "invalid snapshot name '../qa': use letters, digits, '.', '_' and '-'",
+		},
+		{
+			name:          "Unknown snapshot",
+			run:           func(app *App) error { return app.diffSnapshot(context.Background(), "missing") },
+			expectedError: "does not exist (save it first with 'gh topic-urls snapshot save')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app, _ := newSnapshotApp(t.TempDir(), map[string]string{})
+
+			err := tt.run(app)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// statusBatchSize limits how many pull requests are queried per GraphQL request
+const statusBatchSize = 50
+
+// prStatus is the CI and review state of a pull request
+type prStatus struct {
+	// Checks is the statusCheckRollup state of the head commit, e.g. SUCCESS,
+	// FAILURE or PENDING; empty when the commit has no checks
+	Checks string
+	// Review is the reviewDecision, e.g. APPROVED, CHANGES_REQUESTED or
+	// REVIEW_REQUIRED; empty when no review is required
+	Review string
+}
+
+// fetchPullRequestStatuses queries the check and review state of the given pull requests, keyed by number
+func (a *App) fetchPullRequestStatuses(ctx context.Context, repo string, prs []pullRequest) (map[int]prStatus, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository: %s", repo)
+	}
+
+	statuses := make(map[int]prStatus, len(prs))
+	for start := 0; start < len(prs); start += statusBatchSize {
+		end := min(start+statusBatchSize, len(prs))
+
+		output, err := a.ghGraphQL(ctx, buildStatusQuery(prs[start:end]), map[string]string{
+			"owner": owner,
+			"name":  name,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := parseStatusResponse(output, prs[start:end], statuses); err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+// buildStatusQuery builds a GraphQL query with one aliased field per pull request
+func buildStatusQuery(prs []pullRequest) string {
+	var sb strings.Builder
+	sb.WriteString("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) {")
+	for i, pr := range prs {
+		fmt.Fprintf(&sb, " pr%d: pullRequest(number: %d) { reviewDecision commits(last: 1) { nodes { commit { statusCheckRollup { state } } } } }", i, pr.Number)
+	}
+	sb.WriteString(" } }")
+	return sb.String()
+}
+
+// parseStatusResponse adds the statuses of a buildStatusQuery response for prs to statuses
+func parseStatusResponse(data []byte, prs []pullRequest, statuses map[int]prStatus) error {
+	var response struct {
+		Data struct {
+			Repository map[string]*struct {
+				ReviewDecision string `json:"reviewDecision"`
+				Commits        struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+
+	for i, pr := range prs {
+		node := response.Data.Repository[fmt.Sprintf("pr%d", i)]
+		if node == nil {
+			continue
+		}
+		status := prStatus{Review: node.ReviewDecision}
+		if commits := node.Commits.Nodes; len(commits) > 0 && commits[0].Commit.StatusCheckRollup != nil {
+			status.Checks = commits[0].Commit.StatusCheckRollup.State
+		}
+		statuses[pr.Number] = status
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildStatusQuery(t *testing.T) {
+	t.Parallel()
+
+	query := buildStatusQuery([]pullRequest{{Number: 7}, {Number: 12}})
+
+	assert.Contains(t, query, "pr0: pullRequest(number: 7) { reviewDecision commits(last: 1)")
+	assert.Contains(t, query, "pr1: pullRequest(number: 12)")
+	assert.True(t, strings.HasSuffix(query, " } }"))
+}
+
+func TestParseStatusResponse(t *testing.T) {
+	t.Parallel()
+
+	prs := []pullRequest{{Number: 7}, {Number: 12}, {Number: 15}}
+
+	tests := []struct {
+		name        string
+		response    string
+		expected    map[int]prStatus
+		expectError bool
+	}{
+		{
+			name: "Checks and reviews",
+			response: `{"data":{"repository":{
+				"pr0":{"reviewDecision":"APPROVED","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}},
+				"pr1":{"reviewDecision":"CHANGES_REQUESTED","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}},
+				"pr2":{"reviewDecision":null,"commits":{"nodes":[{"commit":{"statusCheckRollup":null}}]}}}}}`,
+			expected: map[int]prStatus{
+				7:  {Checks: "SUCCESS", Review: "APPROVED"},
+				12: {Checks: "FAILURE", Review: "CHANGES_REQUESTED"},
+				15: {},
+			},
+		},
+		{
+			name:     "Missing pull request alias",
+			response: `{"data":{"repository":{"pr0":null}}}`,
+			expected: map[int]prStatus{},
+		},
+		{
+			name:        "GraphQL error",
+			response:    `{"errors":[{"message":"Field 'statusCheckRollup' doesn't exist"}]}`,
+			expectError: true,
+		},
+		{
+			name:        "Invalid JSON",
+			response:    `not json`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Parse response
+			statuses := map[int]prStatus{}
+			err := parseStatusResponse([]byte(tt.response), prs, statuses)
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, statuses)
+		})
+	}
+}
+
+func TestFetchPullRequestStatusesInvalidRepo(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand("", nil))
+
+	_, err := app.fetchPullRequestStatuses(context.Background(), "repo", []pullRequest{{Number: 1}})
+
+	assert.EqualError(t, err, "invalid repository: repo")
+}
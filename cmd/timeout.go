@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+)
+
+// requestTimeout bounds each git command and GitHub request. Commands have no
+// overall deadline: a release range or an organization of hundreds of
+// repositories takes as long as its requests, and prompts wait for the user.
+const requestTimeout = 30 * time.Second
+
+// withRequestTimeout returns ctx bounded by requestTimeout
+func withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, requestTimeout)
+}
+
+// timeoutTransport bounds each request of the next transport by requestTimeout
+type timeoutTransport struct {
+	next apiTransport
+}
+
+// Get performs the request with its own deadline
+func (t timeoutTransport) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	return t.next.Get(ctx, path, header)
+}
+
+// timeoutGitRepo bounds each operation of the wrapped repository by requestTimeout
+type timeoutGitRepo struct {
+	repo topicurls.GitRepo
+}
+
+func (r timeoutGitRepo) RemoteURL(ctx context.Context, remote string) (string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.RemoteURL(ctx, remote)
+}
+
+func (r timeoutGitRepo) CurrentBranch(ctx context.Context) (string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.CurrentBranch(ctx)
+}
+
+func (r timeoutGitRepo) BranchExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.BranchExists(ctx, name)
+}
+
+func (r timeoutGitRepo) Branches(ctx context.Context) ([]topicurls.Branch, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.Branches(ctx)
+}
+
+func (r timeoutGitRepo) RefExists(ctx context.Context, ref string) (bool, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.RefExists(ctx, ref)
+}
+
+func (r timeoutGitRepo) Commits(ctx context.Context, rangeSpec string) ([]string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.Commits(ctx, rangeSpec)
+}
+
+func (r timeoutGitRepo) NameRev(ctx context.Context, rev string) (string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.NameRev(ctx, rev)
+}
+
+func (r timeoutGitRepo) BranchesContaining(ctx context.Context, rev string) ([]string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+	return r.repo.BranchesContaining(ctx, rev)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadlineTransport records the deadline of each request
+type deadlineTransport struct {
+	deadlines []time.Time
+}
+
+func (d *deadlineTransport) Get(ctx context.Context, path string, header http.Header) (*apiResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	deadline, _ := ctx.Deadline()
+	d.deadlines = append(d.deadlines, deadline)
+	return okResponse("", `[]`), nil
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A transport recording request deadlines
+	next := &deadlineTransport{}
+	transport := timeoutTransport{next: next}
+
+	// Act: Send two requests, then one with a cancelled context
+	start := time.Now()
+	for range 2 {
+		_, err := transport.Get(context.Background(), "/repos/owner/repo/pulls", nil)
+		require.NoError(t, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := transport.Get(ctx, "/repos/owner/repo/pulls", nil)
+
+	// Assert: Each request has its own deadline, and cancellation still applies
+	require.Len(t, next.deadlines, 2)
+	for _, deadline := range next.deadlines {
+		assert.WithinDuration(t, start.Add(requestTimeout), deadline, time.Second)
+	}
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTimeoutGitRepo(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: git records the deadline of the context it runs with
+	var deadline time.Time
+	run := func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		deadline, _ = ctx.Deadline()
+		return exec.Command("echo", "-n", "git@github.com:owner/repo.git")
+	}
+	repo := timeoutGitRepo{repo: topicurls.ExecGitRepo{Exec: run}}
+
+	// Act: Read the origin remote
+	start := time.Now()
+	url, err := repo.RemoteURL(context.Background(), "origin")
+
+	// Assert: The git command ran with requestTimeout
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:owner/repo.git", url)
+	assert.WithinDuration(t, start.Add(requestTimeout), deadline, time.Second)
+}
@@ -0,0 +1,632 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiPaneStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8")).Padding(0, 1)
+	tuiFocusedStyle = tuiPaneStyle.BorderForeground(lipgloss.Color("6"))
+	tuiHeaderStyle  = lipgloss.NewStyle().Bold(true)
+	tuiGreenStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	tuiRedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	tuiYellowStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	tuiMagentaStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+)
+
+// newTUICmd builds the tui command
+func newTUICmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Browse topic branches and their pull requests in a terminal dashboard",
+		Long: `Open a dashboard listing the branches of the current repository next to the
+pull requests into the selected branch.
+
+Each pull request shows its state (● open, ✔ merged, ✖ closed), the checks of
+its head commit (✓ passing, ✗ failing, • pending) and its review decision
+(A approved, C changes requested, R review required). "?" marks a status
+that could not be fetched.
+
+Keys:
+  ↑/↓, j/k   move the cursor
+  tab, ←/→   switch between the branch and pull request panes
+  /          filter the focused pane, esc clears the filter
+  space      mark the pull request under the cursor
+  c          copy the marked pull requests, or all listed ones, to the clipboard
+  o          open the pull request under the cursor in the browser
+  f          switch the output format
+  r          reload the pull requests of the branch
+  q          quit`,
+		Example: `  gh topic-urls tui
+  gh topic-urls tui --format plain`,
+		Args:              cobra.NoArgs,
+		RunE:              runTUI,
+		ValidArgsFunction: cobra.NoFileCompletions,
+	}
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	app := appFrom(cmd)
+	ctx := cmd.Context()
+
+	if err := validateFormat(app.Format); err != nil {
+		return err
+	}
+
+	repo, err := app.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	branches, err := app.getAllBranches(ctx)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		return fmt.Errorf("no branches found")
+	}
+
+	// The dashboard stays open as long as the user wants, so only each fetch is bounded
+	_, err = tea.NewProgram(newTUIModel(cmd.Context(), app, repo, branches), tea.WithAltScreen()).Run()
+	return err
+}
+
+// tuiPane identifies a pane of the dashboard
+type tuiPane int
+
+const (
+	tuiBranchPane tuiPane = iota
+	tuiPRPane
+)
+
+// tuiBranchPRs holds the pull requests loaded for a branch
+type tuiBranchPRs struct {
+	prs []pullRequest
+	// statuses is keyed by pull request number; nil when it could not be fetched
+	statuses map[int]prStatus
+	err      error
+	loading  bool
+}
+
+// tuiPRsMsg delivers the pull requests into a branch to the dashboard
+type tuiPRsMsg struct {
+	branch   string
+	prs      []pullRequest
+	statuses map[int]prStatus
+	err      error
+}
+
+// tuiModel is the Bubble Tea model of the dashboard. The left pane lists the
+// branches, filtered by fuzzy match; the right pane lists the pull requests
+// into the branch under the cursor, loaded lazily and cached per branch.
+type tuiModel struct {
+	ctx  context.Context
+	app  *App
+	repo string
+
+	branches []topicurls.Branch
+	// branchMatches holds indexes into branches that match the branch filter, best first
+	branchMatches []int
+	branchFilter  string
+	branchCursor  int
+	branchOffset  int
+
+	// loaded is keyed by branch name
+	loaded map[string]*tuiBranchPRs
+	// prMatches holds indexes into the pull requests of the current branch that match the filter
+	prMatches []int
+	prFilter  string
+	prCursor  int
+	prOffset  int
+	// marked holds the URLs of the pull requests marked for copying
+	marked map[string]bool
+
+	focus     tuiPane
+	filter    textinput.Model
+	filtering bool
+	format    string
+	status    string
+	width     int
+	height    int
+}
+
+// newTUIModel returns a dashboard over the branches of repo with the first branch selected
+func newTUIModel(ctx context.Context, app *App, repo string, branches []topicurls.Branch) tuiModel {
+	filter := textinput.New()
+	filter.Prompt = "/"
+
+	m := tuiModel{
+		ctx:      ctx,
+		app:      app,
+		repo:     repo,
+		branches: branches,
+		loaded:   make(map[string]*tuiBranchPRs),
+		marked:   make(map[string]bool),
+		filter:   filter,
+		format:   app.Format,
+	}
+	m.refilterBranches()
+	return m
+}
+
+func (m tuiModel) Init() tea.Cmd {
+	return m.loadPRs(false)
+}
+
+func (m tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollBranches(0)
+		m.scrollPRs(0)
+		return m, nil
+
+	case tuiPRsMsg:
+		m.loaded[msg.branch] = &tuiBranchPRs{prs: msg.prs, statuses: msg.statuses, err: msg.err}
+		if msg.branch == m.currentBranch() {
+			m.refilterPRs()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		return m.updateKey(msg)
+	}
+
+	if m.filtering {
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateKey handles a key press outside of filtering
+func (m tuiModel) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "tab":
+		m.focus = 1 - m.focus
+	case "left", "h":
+		m.focus = tuiBranchPane
+	case "right", "l", "enter":
+		m.focus = tuiPRPane
+	case "up", "k":
+		return m, m.move(-1)
+	case "down", "j":
+		return m, m.move(1)
+	case "/":
+		m.filtering = true
+		m.filter.SetValue(m.paneFilter())
+		m.filter.CursorEnd()
+		return m, m.filter.Focus()
+	case "esc":
+		if m.paneFilter() != "" {
+			return m, m.setPaneFilter("")
+		}
+	case " ", "x":
+		if pr, ok := m.currentPR(); ok {
+			m.marked[pr.HTMLURL] = !m.marked[pr.HTMLURL]
+		}
+	case "c":
+		m.copySelection()
+	case "o":
+		m.openCurrent()
+	case "f":
+		names := formatNames()
+		m.format = names[(slices.Index(names, m.format)+1)%len(names)]
+		m.status = fmt.Sprintf("Format: %s", m.format)
+	case "r":
+		return m, m.loadPRs(true)
+	}
+	return m, nil
+}
+
+// updateFilter handles a key press while the filter of the focused pane is edited
+func (m tuiModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.filtering = false
+		m.filter.Blur()
+		return m, m.setPaneFilter("")
+	case "enter", "up", "down":
+		m.filtering = false
+		m.filter.Blur()
+		return m.updateKey(msg)
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	if m.filter.Value() != m.paneFilter() {
+		return m, tea.Batch(cmd, m.setPaneFilter(m.filter.Value()))
+	}
+	return m, cmd
+}
+
+func (m tuiModel) View() string {
+	width, height := m.size()
+	branchWidth := max(width/3, 20)
+	prWidth := max(width-branchWidth, 30)
+	// Two border rows plus the header, filter and status lines
+	paneHeight := max(height-5, 3)
+
+	header := tuiHeaderStyle.Render(fmt.Sprintf("%s · format: %s", m.repo, m.format))
+
+	branchPane := m.paneStyle(tuiBranchPane).Width(branchWidth - 2).Height(paneHeight).Render(m.branchPaneView(branchWidth-4, paneHeight))
+	prPane := m.paneStyle(tuiPRPane).Width(prWidth - 2).Height(paneHeight).Render(m.prPaneView(prWidth-4, paneHeight))
+
+	footer := pickerFaintStyle.Render("↑/↓ move · tab switch pane · / filter · space mark · c copy · o open · f format · r reload · q quit")
+	if m.filtering {
+		footer = m.filter.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.JoinHorizontal(lipgloss.Top, branchPane, prPane),
+		footer,
+		m.status,
+	)
+}
+
+// paneStyle returns the border style of a pane, highlighted when focused
+func (m tuiModel) paneStyle(pane tuiPane) lipgloss.Style {
+	if m.focus == pane {
+		return tuiFocusedStyle
+	}
+	return tuiPaneStyle
+}
+
+// branchPaneView renders the branch list
+func (m tuiModel) branchPaneView(width, height int) string {
+	title := fmt.Sprintf("Branches %d/%d", len(m.branchMatches), len(m.branches))
+	if m.branchFilter != "" {
+		title += fmt.Sprintf(" /%s", m.branchFilter)
+	}
+
+	lines := []string{tuiHeaderStyle.Render(title)}
+	end := min(m.branchOffset+m.listHeight(height), len(m.branchMatches))
+	for i := m.branchOffset; i < end; i++ {
+		branch := m.branches[m.branchMatches[i]]
+		line := branch.Name
+		if !branch.CommitDate.IsZero() {
+			line += "  " + pickerFaintStyle.Render(relativeAge(branch.CommitDate, m.app.Now()))
+		}
+		lines = append(lines, m.row(line, i == m.branchCursor, width))
+	}
+	if len(m.branchMatches) == 0 {
+		lines = append(lines, pickerFaintStyle.Render("No matching branches"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prPaneView renders the pull requests into the current branch with their status icons
+func (m tuiModel) prPaneView(width, height int) string {
+	branch := m.currentBranch()
+	title := "Pull requests"
+	if branch != "" {
+		title += " into " + branch
+	}
+	if m.prFilter != "" {
+		title += fmt.Sprintf(" /%s", m.prFilter)
+	}
+	lines := []string{tuiHeaderStyle.Render(title)}
+
+	entry := m.loaded[branch]
+	switch {
+	case branch == "":
+		return strings.Join(lines, "\n")
+	case entry == nil || entry.loading:
+		return strings.Join(append(lines, pickerFaintStyle.Render("Loading…")), "\n")
+	case entry.err != nil:
+		return strings.Join(append(lines, tuiRedStyle.Render(entry.err.Error())), "\n")
+	case len(entry.prs) == 0:
+		return strings.Join(append(lines, pickerFaintStyle.Render("No pull requests")), "\n")
+	case len(m.prMatches) == 0:
+		return strings.Join(append(lines, pickerFaintStyle.Render("No matching pull requests")), "\n")
+	}
+
+	end := min(m.prOffset+m.listHeight(height), len(m.prMatches))
+	for i := m.prOffset; i < end; i++ {
+		pr := entry.prs[m.prMatches[i]]
+		mark := " "
+		if m.marked[pr.HTMLURL] {
+			mark = pickerSelectedStyle.Render("+")
+		}
+		status, known := entry.statuses[pr.Number], entry.statuses != nil
+		line := fmt.Sprintf("%s %s %s %s #%d %s", mark, stateIcon(pr), checksIcon(status, known), reviewIcon(status, known), pr.Number, pr.Title)
+		lines = append(lines, m.row(line, m.focus == tuiPRPane && i == m.prCursor, width))
+	}
+
+	marked := 0
+	for _, pr := range entry.prs {
+		if m.marked[pr.HTMLURL] {
+			marked++
+		}
+	}
+	lines[0] += pickerFaintStyle.Render(fmt.Sprintf("  %d/%d, %d marked", len(m.prMatches), len(entry.prs), marked))
+	return strings.Join(lines, "\n")
+}
+
+// row renders one list line, highlighted under the cursor and cut to width
+func (m tuiModel) row(line string, active bool, width int) string {
+	pointer := "  "
+	if active {
+		pointer = pickerActiveStyle.Render("▸ ")
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(pointer + line)
+}
+
+// size returns the terminal size, with a default before the first WindowSizeMsg
+func (m tuiModel) size() (int, int) {
+	if m.width == 0 || m.height == 0 {
+		return 100, 24
+	}
+	return m.width, m.height
+}
+
+// listHeight returns the number of list rows that fit a pane of the given height
+func (m tuiModel) listHeight(paneHeight int) int {
+	return max(paneHeight-1, 1)
+}
+
+// paneFilter returns the filter of the focused pane
+func (m tuiModel) paneFilter() string {
+	if m.focus == tuiBranchPane {
+		return m.branchFilter
+	}
+	return m.prFilter
+}
+
+// setPaneFilter changes the filter of the focused pane
+func (m *tuiModel) setPaneFilter(query string) tea.Cmd {
+	if m.focus == tuiPRPane {
+		m.prFilter = query
+		m.refilterPRs()
+		return nil
+	}
+	m.branchFilter = query
+	m.refilterBranches()
+	return m.loadPRs(false)
+}
+
+// refilterBranches recomputes the branch matches and resets both cursors
+func (m *tuiModel) refilterBranches() {
+	m.branchCursor, m.branchOffset = 0, 0
+	m.branchMatches = m.branchMatches[:0]
+	if m.branchFilter == "" {
+		for i := range m.branches {
+			m.branchMatches = append(m.branchMatches, i)
+		}
+	} else {
+		for _, match := range fuzzy.FindFrom(m.branchFilter, branchSource(m.branches)) {
+			m.branchMatches = append(m.branchMatches, match.Index)
+		}
+	}
+	m.refilterPRs()
+}
+
+// refilterPRs recomputes the pull requests of the current branch matching
+// the filter by number or title, and resets the cursor
+func (m *tuiModel) refilterPRs() {
+	m.prCursor, m.prOffset = 0, 0
+	m.prMatches = m.prMatches[:0]
+
+	entry := m.loaded[m.currentBranch()]
+	if entry == nil {
+		return
+	}
+	query := strings.ToLower(m.prFilter)
+	for i, pr := range entry.prs {
+		if query == "" || strings.Contains(strings.ToLower(pr.Title), query) || strings.Contains("#"+strconv.Itoa(pr.Number), query) {
+			m.prMatches = append(m.prMatches, i)
+		}
+	}
+}
+
+// move shifts the cursor of the focused pane by delta, loading the pull
+// requests of a newly selected branch
+func (m *tuiModel) move(delta int) tea.Cmd {
+	if m.focus == tuiPRPane {
+		m.scrollPRs(delta)
+		return nil
+	}
+
+	before := m.currentBranch()
+	m.scrollBranches(delta)
+	if m.currentBranch() == before {
+		return nil
+	}
+	m.refilterPRs()
+	return m.loadPRs(false)
+}
+
+// scrollBranches shifts the branch cursor by delta, scrolling the visible window with it
+func (m *tuiModel) scrollBranches(delta int) {
+	m.branchCursor, m.branchOffset = scrollList(m.branchCursor, m.branchOffset, len(m.branchMatches), delta, m.visibleRows())
+}
+
+// scrollPRs shifts the pull request cursor by delta, scrolling the visible window with it
+func (m *tuiModel) scrollPRs(delta int) {
+	m.prCursor, m.prOffset = scrollList(m.prCursor, m.prOffset, len(m.prMatches), delta, m.visibleRows())
+}
+
+// visibleRows returns the number of list rows shown by either pane
+func (m tuiModel) visibleRows() int {
+	_, height := m.size()
+	return m.listHeight(max(height-5, 3))
+}
+
+// scrollList moves a cursor over n rows by delta and returns it with the
+// offset keeping it within a window of size rows
+func scrollList(cursor, offset, n, delta, size int) (int, int) {
+	if n == 0 {
+		return 0, 0
+	}
+	cursor = min(max(cursor+delta, 0), n-1)
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+size {
+		offset = cursor - size + 1
+	}
+	return cursor, offset
+}
+
+// currentBranch returns the name of the branch under the cursor, or "" when no branch matches
+func (m tuiModel) currentBranch() string {
+	if len(m.branchMatches) == 0 {
+		return ""
+	}
+	return m.branches[m.branchMatches[m.branchCursor]].Name
+}
+
+// currentPR returns the pull request under the cursor
+func (m tuiModel) currentPR() (pullRequest, bool) {
+	entry := m.loaded[m.currentBranch()]
+	if entry == nil || len(m.prMatches) == 0 {
+		return pullRequest{}, false
+	}
+	return entry.prs[m.prMatches[m.prCursor]], true
+}
+
+// loadPRs starts fetching the pull requests into the current branch and
+// their statuses, unless they are cached and reload is false
+func (m tuiModel) loadPRs(reload bool) tea.Cmd {
+	branch := m.currentBranch()
+	if branch == "" {
+		return nil
+	}
+	if entry, ok := m.loaded[branch]; ok && (!reload || entry.loading) {
+		return nil
+	}
+	m.loaded[branch] = &tuiBranchPRs{loading: true}
+
+	return func() tea.Msg {
+		prs, err := m.app.fetchPullRequests(m.ctx, m.repo, branch)
+		if err != nil {
+			return tuiPRsMsg{branch: branch, err: err}
+		}
+		// Without statuses the pull requests are still listed, with "?" icons
+		statuses, _ := m.app.fetchPullRequestStatuses(m.ctx, m.repo, prs)
+		return tuiPRsMsg{branch: branch, prs: prs, statuses: statuses}
+	}
+}
+
+// selection returns the marked pull requests of the current branch, or all
+// listed ones when none is marked
+func (m tuiModel) selection() []pullRequest {
+	entry := m.loaded[m.currentBranch()]
+	if entry == nil {
+		return nil
+	}
+
+	var marked, listed []pullRequest
+	for _, i := range m.prMatches {
+		listed = append(listed, entry.prs[i])
+	}
+	for _, pr := range entry.prs {
+		if m.marked[pr.HTMLURL] {
+			marked = append(marked, pr)
+		}
+	}
+	if len(marked) > 0 {
+		return marked
+	}
+	return listed
+}
+
+// copySelection renders the selection with the current format and copies it to the clipboard
+func (m *tuiModel) copySelection() {
+	prs := m.selection()
+	if len(prs) == 0 {
+		m.status = "No pull requests to copy"
+		return
+	}
+
+	branch := m.currentBranch()
+	text, err := renderReport(m.format, report{Sections: []reportSection{{Repo: m.repo, Branch: branch, PullRequests: prs}}})
+	if err == nil {
+		err = m.app.Clipboard(text)
+	}
+	if err != nil {
+		m.status = tuiRedStyle.Render(fmt.Sprintf("Failed to copy: %v", err))
+		return
+	}
+	m.status = fmt.Sprintf("✨ Copied %s as %s", countPullRequests(len(prs)), m.format)
+}
+
+// openCurrent opens the pull request under the cursor in the browser
+func (m *tuiModel) openCurrent() {
+	pr, ok := m.currentPR()
+	if !ok {
+		m.status = "No pull request to open"
+		return
+	}
+	if err := m.app.OpenURL(pr.HTMLURL); err != nil {
+		m.status = tuiRedStyle.Render(fmt.Sprintf("Failed to open #%d: %v", pr.Number, err))
+		return
+	}
+	m.status = fmt.Sprintf("Opened #%d in the browser", pr.Number)
+}
+
+// stateIcon renders the state of a pull request
+func stateIcon(pr pullRequest) string {
+	switch pullRequestState(pr) {
+	case "merged":
+		return tuiMagentaStyle.Render("✔")
+	case "open":
+		return tuiGreenStyle.Render("●")
+	default:
+		return tuiRedStyle.Render("✖")
+	}
+}
+
+// checksIcon renders the check state of a pull request; known is false when it could not be fetched
+func checksIcon(status prStatus, known bool) string {
+	if !known {
+		return pickerFaintStyle.Render("?")
+	}
+	switch status.Checks {
+	case "SUCCESS":
+		return tuiGreenStyle.Render("✓")
+	case "FAILURE", "ERROR":
+		return tuiRedStyle.Render("✗")
+	case "PENDING", "EXPECTED":
+		return tuiYellowStyle.Render("•")
+	default:
+		return " "
+	}
+}
+
+// reviewIcon renders the review decision of a pull request; known is false when it could not be fetched
+func reviewIcon(status prStatus, known bool) string {
+	if !known {
+		return pickerFaintStyle.Render("?")
+	}
+	switch status.Review {
+	case "APPROVED":
+		return tuiGreenStyle.Render("A")
+	case "CHANGES_REQUESTED":
+		return tuiRedStyle.Render("C")
+	case "REVIEW_REQUIRED":
+		return tuiYellowStyle.Render("R")
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tuiStatusResponse answers the status query of tuiRoutes' pull requests into main
+const tuiStatusResponse = `{"data":{"repository":{
+	"pr0":{"reviewDecision":"APPROVED","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}},
+	"pr1":{"reviewDecision":"CHANGES_REQUESTED","commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}}}}}`
+
+// tuiRoutes lists two pull requests into main and one into develop
+var tuiRoutes = map[string]string{
+	"base=main": `[{"number":7,"title":"Add login","state":"open","html_url":"https://github.com/owner/repo/pull/7"},
+		{"number":12,"title":"Fix typo","state":"closed","merged_at":"2025-09-01T00:00:00Z","html_url":"https://github.com/owner/repo/pull/12"}]`,
+	"base=develop": `[{"number":3,"title":"Share tokens","state":"closed","html_url":"https://github.com/owner/repo/pull/3"}]`,
+}
+
+// newTestTUI returns a dashboard over main and develop with a steady filter cursor
+func newTestTUI(app *App) tuiModel {
+	branches := []topicurls.Branch{{Name: "main", CommitDate: testClock.Add(-48 * time.Hour)}, {Name: "develop"}}
+	m := newTUIModel(context.Background(), app, "owner/repo", branches)
+	m.filter.Cursor.SetMode(cursor.CursorStatic)
+	return deliverPRs(m, m.Init())
+}
+
+// pressTUI sends keys to the dashboard and feeds back the pull requests its commands load
+func pressTUI(m tuiModel, keys ...tea.KeyMsg) (tuiModel, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		var model tea.Model
+		model, cmd = m.Update(key)
+		m = deliverPRs(model.(tuiModel), cmd)
+	}
+	return m, cmd
+}
+
+// deliverPRs runs cmd and passes the resulting pull requests to the dashboard
+func deliverPRs(m tuiModel, cmd tea.Cmd) tuiModel {
+	if cmd == nil {
+		return m
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = deliverPRs(m, c)
+		}
+	case tuiPRsMsg:
+		model, _ := m.Update(msg)
+		m = model.(tuiModel)
+	}
+	return m
+}
+
+func TestTUILoadsPullRequestsWithStatuses(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Statuses are answered by gh api graphql
+	app, _ := newTestApp(mockExecCommand(tuiStatusResponse, nil))
+	app.API = newFakeAPI(tuiRoutes)
+
+	// Act: Open the dashboard
+	m := newTestTUI(app)
+	view := m.View()
+
+	// Assert: The branches and the pull requests into the first one with their icons
+	assert.Contains(t, view, "owner/repo · format: markdown")
+	assert.Contains(t, view, "Branches 2/2")
+	assert.Contains(t, view, "▸ main  2d ago")
+	assert.Contains(t, view, "Pull requests into main  2/2, 0 marked")
+	assert.Contains(t, view, "● ✓ A #7 Add login")
+	assert.Contains(t, view, "✔ ✗ C #12 Fix typo")
+
+	// Moving down loads the next branch
+	m, _ = pressTUI(m, keyDown)
+	view = m.View()
+	assert.Contains(t, view, "▸ develop")
+	assert.Contains(t, view, "✖ ✓ A #3 Share tokens")
+}
+
+func TestTUIWithoutStatuses(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: gh api graphql fails
+	app, _ := newTestApp(mockExecCommand("", errors.New("gh: not found")))
+	app.API = newFakeAPI(tuiRoutes)
+
+	// Act: Open the dashboard
+	view := newTestTUI(app).View()
+
+	// Assert: Pull requests are still listed, with unknown statuses
+	assert.Contains(t, view, "● ? ? #7 Add login")
+}
+
+func TestTUIFetchError(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand("", nil))
+	app.API = &fakeAPI{}
+
+	view := newTestTUI(app).View()
+
+	assert.Contains(t, view, "Not Found")
+}
+
+func TestTUIFilter(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newTestApp(mockExecCommand(tuiStatusResponse, nil))
+	app.API = newFakeAPI(tuiRoutes)
+
+	tests := []struct {
+		name        string
+		keys        []tea.KeyMsg
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "Branches are filtered by fuzzy match",
+			keys:        []tea.KeyMsg{typed("/"), typed("dvl")},
+			contains:    []string{"Branches 1/2 /dvl", "▸ develop", "#3 Share tokens"},
+			notContains: []string{"main"},
+		},
+		{
+			name:     "Esc clears the filter",
+			keys:     []tea.KeyMsg{typed("/"), typed("dvl"), keyEsc},
+			contains: []string{"Branches 2/2", "▸ main"},
+		},
+		{
+			name:        "Pull requests are filtered by title",
+			keys:        []tea.KeyMsg{keyTab, typed("/"), typed("typo"), keyEnter},
+			contains:    []string{"Pull requests into main /typo  1/2", "▸   ✔ ✗ C #12 Fix typo"},
+			notContains: []string{"Add login"},
+		},
+		{
+			name:        "Pull requests are filtered by number",
+			keys:        []tea.KeyMsg{keyTab, typed("/"), typed("#7")},
+			contains:    []string{"#7 Add login"},
+			notContains: []string{"Fix typo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Type the filter
+			m, _ := pressTUI(newTestTUI(app), tt.keys...)
+			view := m.View()
+
+			// Assert: Only the matching rows are listed
+			for _, s := range tt.contains {
+				assert.Contains(t, view, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, view, s)
+			}
+		})
+	}
+}
+
+func TestTUIActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		keys           []tea.KeyMsg
+		expectedClip   string
+		expectedOpened string
+		expectedStatus string
+	}{
+		{
+			name:           "c copies every listed pull request",
+			keys:           []tea.KeyMsg{typed("c")},
+			expectedClip:   "- https://github.com/owner/repo/pull/7\n- https://github.com/owner/repo/pull/12\n",
+			expectedStatus: "✨ Copied 2 pull requests as markdown",
+		},
+		{
+			name:           "c copies only the marked pull requests",
+			keys:           []tea.KeyMsg{keyTab, keyDown, typed(" "), typed("c")},
+			expectedClip:   "- https://github.com/owner/repo/pull/12\n",
+			expectedStatus: "✨ Copied 1 pull request as markdown",
+		},
+		{
+			name:           "f switches the format of the copy",
+			keys:           []tea.KeyMsg{typed("f"), typed("c")},
+			expectedClip:   "https://github.com/owner/repo/pull/7\nhttps://github.com/owner/repo/pull/12\n",
+			expectedStatus: "✨ Copied 2 pull requests as plain",
+		},
+		{
+			name:           "o opens the pull request under the cursor",
+			keys:           []tea.KeyMsg{keyTab, keyDown, typed("o")},
+			expectedOpened: "https://github.com/owner/repo/pull/12",
+			expectedStatus: "Opened #12 in the browser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: Record the clipboard and the opened URL
+			app, _ := newTestApp(mockExecCommand(tuiStatusResponse, nil))
+			app.API = newFakeAPI(tuiRoutes)
+			var clip, opened string
+			app.Clipboard = func(text string) error {
+				clip = text
+				return nil
+			}
+			app.OpenURL = func(url string) error {
+				opened = url
+				return nil
+			}
+
+			// Act: Press the keys
+			m, _ := pressTUI(newTestTUI(app), tt.keys...)
+
+			// Assert: The clipboard, the browser and the status line
+			assert.Equal(t, tt.expectedClip, clip)
+			assert.Equal(t, tt.expectedOpened, opened)
+			assert.Equal(t, tt.expectedStatus, m.status)
+		})
+	}
+}
+
+func TestTUIReloadAndQuit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Count the pull request requests
+	app, _ := newTestApp(mockExecCommand(tuiStatusResponse, nil))
+	api := newFakeAPI(tuiRoutes)
+	app.API = api
+	m := newTestTUI(app)
+
+	// Act & Assert: Returning to a branch uses the cache, r fetches it again
+	m, _ = pressTUI(m, keyDown, typed("k"))
+	assert.Len(t, api.paths(), 2)
+	m, _ = pressTUI(m, typed("r"))
+	assert.Len(t, api.paths(), 3)
+
+	_, cmd := pressTUI(m, typed("q"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
+
+func TestScrollList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		cursor, offset, delta  int
+		expectedCursor, expOff int
+	}{
+		{name: "Move within the window", cursor: 0, offset: 0, delta: 1, expectedCursor: 1, expOff: 0},
+		{name: "Scroll down past the window", cursor: 2, offset: 0, delta: 1, expectedCursor: 3, expOff: 1},
+		{name: "Scroll up past the window", cursor: 3, offset: 3, delta: -1, expectedCursor: 2, expOff: 2},
+		{name: "Stop at the last row", cursor: 9, offset: 7, delta: 1, expectedCursor: 9, expOff: 7},
+		{name: "Stop at the first row", cursor: 0, offset: 0, delta: -1, expectedCursor: 0, expOff: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, offset := scrollList(tt.cursor, tt.offset, 10, tt.delta, 3)
+
+			assert.Equal(t, tt.expectedCursor, cursor)
+			assert.Equal(t, tt.expOff, offset)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultWatchInterval is the polling interval of --watch
+const defaultWatchInterval = 2 * time.Minute
+
+// prChange is a difference between two polls of a watched branch
+type prChange struct {
+	// Kind is "added", "merged", "closed", "reopened" or "removed"
+	Kind string
+	PR   pullRequest
+}
+
+// changeSymbols prefixes each kind of change in the watch output
+var changeSymbols = map[string]string{
+	"added":    "+",
+	"merged":   "✔",
+	"closed":   "✖",
+	"reopened": "↺",
+	"removed":  "-",
+}
+
+// String renders a change as one line of the watch output
+func (c prChange) String() string {
+	return fmt.Sprintf("%s #%d %s (%s)", changeSymbols[c.Kind], c.PR.Number, c.PR.Title, c.Kind)
+}
+
+// diffPullRequests returns the changes from before to after, in the order of
+// after followed by the pull requests that no longer target the branch
+func diffPullRequests(before, after []pullRequest) []prChange {
+	previous := make(map[string]pullRequest, len(before))
+	for _, pr := range before {
+		previous[pr.HTMLURL] = pr
+	}
+
+	var changes []prChange
+	current := make(map[string]bool, len(after))
+	for _, pr := range after {
+		current[pr.HTMLURL] = true
+
+		old, ok := previous[pr.HTMLURL]
+		if !ok {
+			changes = append(changes, prChange{Kind: "added", PR: pr})
+			continue
+		}
+
+		state := pullRequestState(pr)
+		if state == pullRequestState(old) {
+			continue
+		}
+		switch state {
+		case "merged", "closed":
+			changes = append(changes, prChange{Kind: state, PR: pr})
+		case "open":
+			changes = append(changes, prChange{Kind: "reopened", PR: pr})
+		}
+	}
+
+	for _, pr := range before {
+		if !current[pr.HTMLURL] {
+			changes = append(changes, prChange{Kind: "removed", PR: pr})
+		}
+	}
+	return changes
+}
+
+// watchTopicUrls lists the pull requests into branch, then polls every
+// interval and prints what changed, copying the new list only on change. It
+// returns when ctx is cancelled.
+func (a *App) watchTopicUrls(ctx context.Context, branch string, interval time.Duration, notify bool) error {
+	repo, err := a.getCurrentRepo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current repository: %w", err)
+	}
+
+	fmt.Fprintf(a.Stderr, "Watching '%s' every %s (Ctrl+C to stop)\n", branch, interval)
+
+	prs, err := a.fetchPullRequests(ctx, repo, branch)
+	if err != nil {
+		return err
+	}
+	if err := a.outputWatched(ctx, repo, branch, prs); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	notifyFailed := false
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(a.Stderr, "Stopped watching")
+			return nil
+		case <-ticker.C:
+		}
+
+		latest, err := a.fetchPullRequests(ctx, repo, branch)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			// Keep watching through transient failures such as network errors
+			fmt.Fprintf(a.Stderr, "%s poll failed: %v\n", a.Now().Format(time.TimeOnly), err)
+			continue
+		}
+
+		changes := diffPullRequests(prs, latest)
+		prs = latest
+		if len(changes) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(a.Stdout, "%s %s:\n", a.Now().Format(time.TimeOnly), describeChanges(changes))
+		for _, change := range changes {
+			fmt.Fprintf(a.Stdout, "  %s\n", change)
+		}
+
+		if notify && !notifyFailed {
+			if err := a.Notify(fmt.Sprintf("gh topic-urls: %s", branch), describeChanges(changes)); err != nil {
+				// Report once; the watch itself still works
+				fmt.Fprintf(a.Stderr, "desktop notification failed: %v\n", err)
+				notifyFailed = true
+			}
+		}
+
+		if err := a.copyPullRequests(ctx, repo, prs); err != nil {
+			return err
+		}
+	}
+}
+
+// outputWatched prints and copies the pull requests of a poll, or notes that there are none
+func (a *App) outputWatched(ctx context.Context, repo, branch string, prs []pullRequest) error {
+	if len(prs) == 0 {
+		fmt.Fprintf(a.Stderr, "No pull requests found for branch '%s'\n", branch)
+		return nil
+	}
+	return a.outputPullRequests(ctx, repo, prs)
+}
+
+// copyPullRequests renders the pull requests in the selected format and
+// copies them to the clipboard without printing them. Without pull requests
+// the clipboard is cleared, so it never holds a list that is no longer current.
+func (a *App) copyPullRequests(ctx context.Context, repo string, prs []pullRequest) error {
+	if len(prs) == 0 {
+		if err := a.Clipboard(""); err != nil {
+			return fmt.Errorf("clipboard copy error: %w", err)
+		}
+		fmt.Fprintln(a.Stderr, "✨ Cleared the clipboard")
+		return nil
+	}
+
+	section := reportSection{PullRequests: prs}
+	if a.WithIssues {
+		section.Issues = a.collectResolvedIssues(ctx, repo, prs)
+	}
+
+	text, err := renderReport(a.Format, report{Sections: []reportSection{section}})
+	if err != nil {
+		return err
+	}
+	if err := a.Clipboard(text); err != nil {
+		return fmt.Errorf("clipboard copy error: %w", err)
+	}
+
+	fmt.Fprintln(a.Stderr, "✨ Copied to clipboard")
+	return nil
+}
+
+// describeChanges summarizes changes by kind, e.g. "1 added, 2 merged"
+func describeChanges(changes []prChange) string {
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Kind]++
+	}
+
+	var parts []string
+	for _, kind := range []string{"added", "merged", "closed", "reopened", "removed"} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
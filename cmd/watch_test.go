@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffPullRequests(t *testing.T) {
+	t.Parallel()
+
+	merged := time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC)
+	open7 := pullRequest{Number: 7, Title: "Add login", State: "open", HTMLURL: "https://github.com/owner/repo/pull/7"}
+	merged7 := open7
+	merged7.State, merged7.MergedAt = "closed", &merged
+	open12 := pullRequest{Number: 12, Title: "Fix typo", State: "open", HTMLURL: "https://github.com/owner/repo/pull/12"}
+	closed12 := open12
+	closed12.State = "closed"
+	open15 := pullRequest{Number: 15, Title: "Drop legacy API", State: "open", HTMLURL: "https://github.com/owner/repo/pull/15"}
+
+	tests := []struct {
+		name     string
+		before   []pullRequest
+		after    []pullRequest
+		expected []string
+	}{
+		{name: "No change", before: []pullRequest{open7}, after: []pullRequest{open7}},
+		{name: "Added", before: []pullRequest{open7}, after: []pullRequest{open7, open12}, expected: []string{"+ #12 Fix typo (added)"}},
+		{name: "Merged", before: []pullRequest{open7}, after: []pullRequest{merged7}, expected: []string{"✔ #7 Add login (merged)"}},
+		{name: "Closed", before: []pullRequest{open12}, after: []pullRequest{closed12}, expected: []string{"✖ #12 Fix typo (closed)"}},
+		{name: "Reopened", before: []pullRequest{closed12}, after: []pullRequest{open12}, expected: []string{"↺ #12 Fix typo (reopened)"}},
+		{name: "Retargeted away", before: []pullRequest{open7, open15}, after: []pullRequest{open7}, expected: []string{"- #15 Drop legacy API (removed)"}},
+		{
+			name:     "Several changes",
+			before:   []pullRequest{open7, open12},
+			after:    []pullRequest{merged7, closed12, open15},
+			expected: []string{"✔ #7 Add login (merged)", "✖ #12 Fix typo (closed)", "+ #15 Drop legacy API (added)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var lines []string
+			for _, change := range diffPullRequests(tt.before, tt.after) {
+				lines = append(lines, change.String())
+			}
+
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := []prChange{{Kind: "merged"}, {Kind: "added"}, {Kind: "merged"}}
+
+	assert.Equal(t, "1 added, 2 merged", describeChanges(changes))
+}
+
+func TestWatchTopicUrls(t *testing.T) {
+	t.Parallel()
+
+	pr7 := `{"number":7,"title":"Add login","state":"open","html_url":"https://github.com/owner/repo/pull/7"}`
+	merged7 := `{"number":7,"title":"Add login","state":"closed","merged_at":"2025-09-06T11:00:00Z","html_url":"https://github.com/owner/repo/pull/7"}`
+	pr12 := `{"number":12,"title":"Fix typo","state":"open","html_url":"https://github.com/owner/repo/pull/12"}`
+
+	// Arrange: Polls return one PR, the same, a failure, a merge and a new PR, then none
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	app, out := newTestApp(mockGitCommands(map[string]string{"remote get-url origin": "git@github.com:owner/repo.git"}))
+	api := (&fakeAPI{}).on("base=release%2Fnext",
+		okResponse("", "["+pr7+"]"), okResponse("", "["+pr7+"]"), nil, okResponse("", "["+merged7+","+pr12+"]"), okResponse("", "[]"))
+	api.onRequest = func(n int) {
+		if n == 5 {
+			cancel()
+		}
+	}
+	app.API = api
+	var clips []string
+	app.Clipboard = func(text string) error {
+		clips = append(clips, text)
+		return nil
+	}
+	var notifications []string
+	app.Notify = func(title, message string) error {
+		notifications = append(notifications, title+": "+message)
+		return nil
+	}
+
+	// Act: Watch until the last poll
+	err := app.watchTopicUrls(ctx, "release/next", time.Millisecond, true)
+
+	// Assert: The list once, then only the changes; the clipboard follows the changes
+	require.NoError(t, err)
+	assert.Equal(t, "Watching 'release/next' every 1ms (Ctrl+C to stop)\n"+
+		"- https://github.com/owner/repo/pull/7\n"+
+		"✨ Copied to clipboard\n"+
+		"12:00:00 poll failed: connection reset\n"+
+		"12:00:00 1 added, 1 merged:\n"+
+		"  ✔ #7 Add login (merged)\n"+
+		"  + #12 Fix typo (added)\n"+
+		"✨ Copied to clipboard\n"+
+		"12:00:00 2 removed:\n"+
+		"  - #7 Add login (removed)\n"+
+		"  - #12 Fix typo (removed)\n"+
+		"✨ Cleared the clipboard\n"+
+		"Stopped watching\n", out.String())
+	assert.Equal(t, []string{
+		"- https://github.com/owner/repo/pull/7\n",
+		"- https://github.com/owner/repo/pull/7\n- https://github.com/owner/repo/pull/12\n",
+		"",
+	}, clips)
+	assert.Equal(t, []string{"gh topic-urls: release/next: 1 added, 1 merged", "gh topic-urls: release/next: 2 removed"}, notifications)
+}
+
+func TestWatchOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          rootOptions
+		expectedError string
+	}{
+		{
+			name:          "Combined with --pick",
+			opts:          rootOptions{Watch: true, Interval: time.Minute, Pick: true},
+			expectedError: "--watch cannot be combined with --pick, --web, --open, --auto, --from-commits or multi-repository flags",
+		},
+		{
+			name:          "Zero interval",
+			opts:          rootOptions{Watch: true},
+			expectedError: "--interval must be positive",
+		},
+		{
+			name:          "--notify without --watch",
+			opts:          rootOptions{Notify: true},
+			expectedError: "--notify requires --watch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app, _ := newTestApp(mockExecCommand("", nil))
+
+			err := app.runTopicUrls(context.Background(), tt.opts, []string{"release/next"})
+
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestNotifyCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goos          string
+		expectedName  string
+		expectedArgs  []string
+		expectedError bool
+	}{
+		{goos: "darwin", expectedName: "osascript", expectedArgs: []string{"-e", `display notification "1 merged" with title "gh \"topic\""`}},
+		{goos: "linux", expectedName: "notify-send", expectedArgs: []string{`gh "topic"`, "1 merged"}},
+		{goos: "windows", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			t.Parallel()
+
+			name, args, err := notifyCommand(tt.goos, `gh "topic"`, "1 merged")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
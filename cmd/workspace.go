@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Yuki-Sakaguchi/gh-topic-urls/pkg/topicurls"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// workspaceFileName is the manifest looked up in the current directory and its parents
+const workspaceFileName = "topic-urls.workspace.yml"
+
+// workspaceManifest lists the repositories of a multi-repository feature
+type workspaceManifest struct {
+	Title  string          `yaml:"title"`
+	Branch string          `yaml:"branch"`
+	Repos  []workspaceRepo `yaml:"repos"`
+}
+
+// workspaceRepo is one repository entry of a workspace manifest.
+// Either repo (owner/name) or remote (a Git remote URL) identifies the repository.
+type workspaceRepo struct {
+	Repo   string `yaml:"repo"`
+	Remote string `yaml:"remote"`
+	Branch string `yaml:"branch"`
+	Alias  string `yaml:"alias"`
+}
+
+// workspaceOptions holds the flags of the workspace report command
+type workspaceOptions struct {
+	File   string
+	Branch string
+}
+
+// newWorkspaceCmd builds the workspace command and its subcommands
+func newWorkspaceCmd() *cobra.Command {
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Work with a multi-repository workspace manifest",
+	}
+	workspaceCmd.AddCommand(newWorkspaceReportCmd())
+	return workspaceCmd
+}
+
+func newWorkspaceReportCmd() *cobra.Command {
+	var opts workspaceOptions
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report pull requests for every repository in the workspace manifest",
+		Long: `Fetch the pull requests into the topic branch of every repository listed in
+` + workspaceFileName + ` and print them as a single document.
+
+The manifest is looked up in the current directory and its parents:
+
+  title: Payments v2
+  branch: feature/payments-v2
+  repos:
+    - repo: your-org/api
+      alias: Payments API
+    - remote: git@github.com:your-org/web.git
+      branch: feature/payments-v2-web`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspaceReport(cmd, opts)
+		},
+	}
+
+	reportCmd.Flags().StringVar(&opts.File, "file", "", "Path to the workspace manifest (default: search for "+workspaceFileName+")")
+	reportCmd.Flags().StringVar(&opts.Branch, "branch", "", "Override the manifest's default topic branch")
+
+	return reportCmd
+}
+
+func runWorkspaceReport(cmd *cobra.Command, opts workspaceOptions) error {
+	app := appFrom(cmd)
+	ctx := cmd.Context()
+
+	if err := validateFormat(app.Format); err != nil {
+		return err
+	}
+
+	path := opts.File
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+		path, err = findWorkspaceManifest(cwd)
+		if err != nil {
+			return err
+		}
+	}
+
+	manifest, err := loadWorkspaceManifest(path)
+	if err != nil {
+		return err
+	}
+	if opts.Branch != "" {
+		manifest.Branch = opts.Branch
+	}
+
+	queries, err := manifest.queries()
+	if err != nil {
+		return fmt.Errorf("invalid workspace manifest %s: %w", path, err)
+	}
+
+	fmt.Fprintf(app.Stderr, "Workspace: %s (%d repositories)\n", path, len(queries))
+
+	if err := app.getMultiRepoTopicUrls(ctx, report{Title: manifest.Title}, queries); err != nil {
+		return fmt.Errorf("failed to get pull requests: %w", err)
+	}
+	return nil
+}
+
+// findWorkspaceManifest searches dir and its parents for the workspace manifest
+func findWorkspaceManifest(dir string) (string, error) {
+	for {
+		path := filepath.Join(dir, workspaceFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("%s not found in the current directory or its parents", workspaceFileName)
+		}
+		dir = parent
+	}
+}
+
+// loadWorkspaceManifest reads and parses a workspace manifest
+func loadWorkspaceManifest(path string) (*workspaceManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("workspace manifest %s does not exist", path)
+		}
+		return nil, fmt.Errorf("failed to read workspace manifest: %w", err)
+	}
+
+	return parseWorkspaceManifest(data)
+}
+
+// parseWorkspaceManifest decodes a workspace manifest, rejecting unknown fields
+func parseWorkspaceManifest(data []byte) (*workspaceManifest, error) {
+	var manifest workspaceManifest
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// queries resolves the manifest entries into repository queries, applying
+// per-repository branch overrides and aliases
+func (m *workspaceManifest) queries() ([]repoQuery, error) {
+	if len(m.Repos) == 0 {
+		return nil, fmt.Errorf("no repositories listed")
+	}
+
+	queries := make([]repoQuery, 0, len(m.Repos))
+	for i, entry := range m.Repos {
+		repo, err := entry.resolveRepo()
+		if err != nil {
+			return nil, fmt.Errorf("repos[%d]: %w", i, err)
+		}
+
+		branch := entry.Branch
+		if branch == "" {
+			branch = m.Branch
+		}
+		if branch == "" {
+			return nil, fmt.Errorf("repos[%d]: no branch set for %s and no default branch", i, repo)
+		}
+
+		title := entry.Alias
+		if title == "" {
+			title = repo
+		}
+
+		queries = append(queries, repoQuery{Repo: repo, Branch: branch, Title: title})
+	}
+
+	return queries, nil
+}
+
+// resolveRepo returns the owner/name of a manifest entry
+func (r workspaceRepo) resolveRepo() (string, error) {
+	repo, remote := strings.TrimSpace(r.Repo), strings.TrimSpace(r.Remote)
+	switch {
+	case repo != "" && remote != "":
+		return "", fmt.Errorf("set either repo or remote, not both")
+	case remote != "":
+		return topicurls.ParseRepoFromURL(remote)
+	case repo != "":
+		repos, err := parseRepoList([]string{repo})
+		if err != nil {
+			return "", err
+		}
+		return repos[0], nil
+	default:
+		return "", fmt.Errorf("repo or remote is required")
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkspaceManifest(t *testing.T) {
+	tests := []struct {
+		name        string
+		manifest    string
+		expected    []repoQuery
+		expectError bool
+	}{
+		{
+			name: "Default branch with overrides and aliases",
+			manifest: `title: Payments v2
+branch: feature/payments-v2
+repos:
+  - repo: org/api
+    alias: Payments API
+  - remote: git@github.com:org/web.git
+    branch: feature/payments-v2-web
+  - remote: https://github.com/org/worker.git
+`,
+			expected: []repoQuery{
+				{Repo: "org/api", Branch: "feature/payments-v2", Title: "Payments API"},
+				{Repo: "org/web", Branch: "feature/payments-v2-web", Title: "org/web"},
+				{Repo: "org/worker", Branch: "feature/payments-v2", Title: "org/worker"},
+			},
+		},
+		{
+			name: "Missing branch",
+			manifest: `repos:
+  - repo: org/api
+`,
+			expectError: true,
+		},
+		{
+			name:        "No repositories",
+			manifest:    "branch: main\n",
+			expectError: true,
+		},
+		{
+			name: "Both repo and remote",
+			manifest: `branch: main
+repos:
+  - repo: org/api
+    remote: git@github.com:org/api.git
+`,
+			expectError: true,
+		},
+		{
+			name: "Whitespace-only repo",
+			manifest: `branch: main
+repos:
+  - repo: " "
+`,
+			expectError: true,
+		},
+		{
+			name: "Unsupported remote",
+			manifest: `branch: main
+repos:
+  - remote: ftp://example.com/repo.git
+`,
+			expectError: true,
+		},
+		{
+			name: "Unknown field",
+			manifest: `branch: main
+repos:
+  - repository: org/api
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act: Parse manifest and resolve queries
+			manifest, err := parseWorkspaceManifest([]byte(tt.manifest))
+			var queries []repoQuery
+			if err == nil {
+				queries, err = manifest.queries()
+			}
+
+			// Assert: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, queries)
+			}
+		})
+	}
+}
+
+func TestFindWorkspaceManifest(t *testing.T) {
+	// Arrange: Manifest in the root of a nested directory tree
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	manifestPath := filepath.Join(root, workspaceFileName)
+	assert.NoError(t, os.WriteFile(manifestPath, []byte("branch: main\n"), 0o644))
+
+	// Act: Search from the nested directory
+	path, err := findWorkspaceManifest(nested)
+
+	// Assert: Manifest in a parent directory is found
+	assert.NoError(t, err)
+	assert.Equal(t, manifestPath, path)
+
+	// A tree without a manifest reports an error
+	_, err = findWorkspaceManifest(t.TempDir())
+	assert.Error(t, err)
+}
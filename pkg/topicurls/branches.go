@@ -0,0 +1,172 @@
+package topicurls
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Branch is a local or remote branch listed by GitRepo.Branches
+type Branch struct {
+	// Name is the branch name without refs/heads/ or the remote prefix
+	Name string
+	// Remote is "origin" for a branch only found there; empty for local branches
+	Remote string
+	// Upstream is the remote-tracking branch of a local branch, e.g. "origin/main"
+	Upstream string
+	// CommitDate is the committer date of the branch tip
+	CommitDate time.Time
+	// Author is the author name of the branch tip
+	Author string
+}
+
+// BranchNames returns the names of branches, keeping their order
+func BranchNames(branches []Branch) []string {
+	names := make([]string, 0, len(branches))
+	for _, b := range branches {
+		names = append(names, b.Name)
+	}
+	return names
+}
+
+// forEachRefFormat prints the fields read by parseForEachRef, tab separated.
+// Ref names cannot contain tabs, and the free-form author name comes last.
+const forEachRefFormat = "%(refname)%09%(upstream:short)%09%(committerdate:iso-strict)%09%(authorname)"
+
+// parseForEachRef parses git for-each-ref output in forEachRefFormat
+func parseForEachRef(output string) []Branch {
+	var branches []Branch
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), "\t", 4)
+		if len(fields) != 4 {
+			continue
+		}
+
+		name, remote, ok := splitBranchRef(fields[0])
+		if !ok {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		branches = append(branches, Branch{
+			Name:       name,
+			Remote:     remote,
+			Upstream:   fields[1],
+			CommitDate: date,
+			Author:     fields[3],
+		})
+	}
+
+	return dedupeBranches(branches)
+}
+
+// splitBranchRef splits refs/heads/<name> and refs/remotes/origin/<name> into
+// the branch name and remote. Branches of other remotes are rejected: their pull
+// requests live in another repository and BranchExists does not find them.
+// Remote HEAD pointers and other refs are rejected too.
+func splitBranchRef(ref string) (name, remote string, ok bool) {
+	if name, found := strings.CutPrefix(ref, "refs/heads/"); found {
+		return name, "", name != ""
+	}
+
+	name, found := strings.CutPrefix(ref, "refs/remotes/origin/")
+	if !found || name == "" || name == "HEAD" {
+		return "", "", false
+	}
+	return name, "origin", true
+}
+
+// dedupeBranches keeps one record per branch name, preferring the local branch
+// over origin, and orders them most recently committed first
+func dedupeBranches(branches []Branch) []Branch {
+	best := make(map[string]int, len(branches))
+	var unique []Branch
+	for _, b := range branches {
+		i, seen := best[b.Name]
+		if !seen {
+			best[b.Name] = len(unique)
+			unique = append(unique, b)
+			continue
+		}
+		if b.Remote == "" && unique[i].Remote != "" {
+			unique[i] = b
+		}
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		if !unique[i].CommitDate.Equal(unique[j].CommitDate) {
+			return unique[i].CommitDate.After(unique[j].CommitDate)
+		}
+		return unique[i].Name < unique[j].Name
+	})
+	return unique
+}
+
+// maxSuggestions caps the branches returned by SuggestBranches
+const maxSuggestions = 3
+
+// SuggestBranches returns the candidates within a small edit distance of name,
+// closest first, for "did you mean" hints
+func SuggestBranches(name string, candidates []string) []string {
+	// Allow roughly one typo per four characters, and always at least two
+	limit := max(2, len([]rune(name))/4)
+
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	var suggestions []suggestion
+	seen := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		if candidate == name || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		if d := editDistance(name, candidate); d <= limit {
+			suggestions = append(suggestions, suggestion{name: candidate, distance: d})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	var names []string
+	for _, s := range suggestions[:min(len(suggestions), maxSuggestions)] {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b, counting
+// an adjacent transposition as a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// prev2 and prev are the two rows of the distance matrix above curr
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
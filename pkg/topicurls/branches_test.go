@@ -0,0 +1,119 @@
+package topicurls
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// refLine formats a git for-each-ref line in forEachRefFormat
+func refLine(ref, upstream, date, author string) string {
+	return strings.Join([]string{ref, upstream, date, author}, "\t")
+}
+
+func TestParseForEachRef(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2025, 9, d, 10, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		output   string
+		expected []Branch
+	}{
+		{
+			name: "Local branch shadows its remote-tracking branches",
+			output: strings.Join([]string{
+				refLine("refs/heads/main", "origin/main", "2025-09-03T10:00:00Z", "Alice"),
+				refLine("refs/remotes/origin/main", "", "2025-09-03T10:00:00Z", "Alice"),
+				refLine("refs/remotes/upstream/main", "", "2025-09-04T10:00:00Z", "Bob"),
+			}, "\n"),
+			expected: []Branch{{Name: "main", Upstream: "origin/main", CommitDate: day(3), Author: "Alice"}},
+		},
+		{
+			name: "Branches only on other remotes than origin are left out",
+			output: strings.Join([]string{
+				refLine("refs/remotes/upstream/release/next", "", "2025-09-05T10:00:00Z", "Bob"),
+				refLine("refs/remotes/origin/release/next", "", "2025-09-02T10:00:00Z", "Alice"),
+				refLine("refs/remotes/upstream/x", "", "2025-09-01T10:00:00Z", "Carol"),
+			}, "\n"),
+			expected: []Branch{
+				{Name: "release/next", Remote: "origin", CommitDate: day(2), Author: "Alice"},
+			},
+		},
+		{
+			name: "Remote HEAD pointers and malformed lines are skipped",
+			output: strings.Join([]string{
+				refLine("refs/remotes/origin/HEAD", "", "2025-09-03T10:00:00Z", "Alice"),
+				"garbage",
+				refLine("refs/tags/v1.0.0", "", "2025-09-03T10:00:00Z", "Alice"),
+			}, "\n"),
+		},
+		{
+			name:   "Empty output",
+			output: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, normalizeDates(parseForEachRef(tt.output)))
+		})
+	}
+}
+
+func TestBranchNames(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"main", "develop"}, BranchNames([]Branch{{Name: "main"}, {Name: "develop", Remote: "origin"}}))
+	assert.Equal(t, []string{}, BranchNames(nil))
+}
+
+func TestSuggestBranches(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"main", "develop", "release/next", "release/1.2", "feature/payments", "feature/payment-ui"}
+
+	tests := []struct {
+		name     string
+		branch   string
+		expected []string
+	}{
+		{name: "Transposed letters", branch: "release/nxet", expected: []string{"release/next"}},
+		{name: "Missing letter", branch: "mai", expected: []string{"main"}},
+		{name: "Closest first", branch: "feature/payment", expected: []string{"feature/payments", "feature/payment-ui"}},
+		{name: "Nothing similar", branch: "hotfix/urgent", expected: nil},
+		{name: "Exact match is not suggested", branch: "main", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, SuggestBranches(tt.branch, candidates))
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"main", "", 4},
+		{"main", "main", 0},
+		{"main", "mian", 1},
+		{"kitten", "sitting", 3},
+		{"release/next", "release/nxet", 1},
+		{"ブランチ", "ブランク", 1},
+	} {
+		assert.Equal(t, tt.expected, editDistance(tt.a, tt.b), "%s -> %s", tt.a, tt.b)
+	}
+}
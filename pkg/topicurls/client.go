@@ -0,0 +1,206 @@
+package topicurls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pullRequestsPerPage is the page size requested from the pulls endpoint
+const pullRequestsPerPage = 100
+
+// PullRequest holds the fields of a GitHub pull request
+type PullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	HTMLURL  string     `json:"html_url"`
+	State    string     `json:"state"`
+	Body     string     `json:"body"`
+	MergedAt *time.Time `json:"merged_at"`
+	Labels   []Label    `json:"labels"`
+	Base     BranchRef  `json:"base"`
+	Head     BranchRef  `json:"head"`
+}
+
+// BranchRef is the base or head branch of a pull request
+type BranchRef struct {
+	Ref string `json:"ref"`
+}
+
+// Label is a GitHub issue or pull request label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Client queries the GitHub REST API through a Transport
+type Client struct {
+	Transport Transport
+}
+
+// NewClient returns a Client sending its requests through t
+func NewClient(t Transport) *Client {
+	return &Client{Transport: t}
+}
+
+// ListOptions narrows the pull requests returned by ListTopicPRs
+type ListOptions struct {
+	// State is "open", "closed" or "all" (the default)
+	State string
+	// MergedOnly drops pull requests that were closed without being merged or are still open
+	MergedOnly bool
+}
+
+// APIError is returned for a response with an unsuccessful status
+type APIError struct {
+	StatusCode int
+	// Message is the "message" field of the error body, or the body itself
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gh api error: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// Get performs a GET request and returns the body of a successful response;
+// other responses are returned as an *APIError
+func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	resp, err := c.Transport.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: apiErrorMessage(resp.Body)}
+	}
+
+	return resp.Body, nil
+}
+
+// ListTopicPRs returns the pull requests whose base is branch in repo (owner/name),
+// oldest first. A nil opts lists pull requests in every state.
+func (c *Client) ListTopicPRs(ctx context.Context, repo, branch string, opts *ListOptions) ([]PullRequest, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	state := opts.State
+	if state == "" {
+		state = "all"
+	}
+
+	var prs []PullRequest
+	for page := 1; ; page++ {
+		output, err := c.Get(ctx, pullsPath(repo, url.Values{
+			"state":     {state},
+			"base":      {branch},
+			"sort":      {"created"},
+			"direction": {"asc"},
+			"per_page":  {strconv.Itoa(pullRequestsPerPage)},
+			"page":      {strconv.Itoa(page)},
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		var pagePRs []PullRequest
+		if err := json.Unmarshal(output, &pagePRs); err != nil {
+			return nil, fmt.Errorf("failed to decode pull requests: %w", err)
+		}
+
+		for _, pr := range pagePRs {
+			if opts.MergedOnly && pr.MergedAt == nil {
+				continue
+			}
+			prs = append(prs, pr)
+		}
+		if len(pagePRs) < pullRequestsPerPage {
+			break
+		}
+	}
+
+	return prs, nil
+}
+
+// CountTopicPRs returns the number of pull requests in every state whose base
+// is branch in repo, counting at most limit (up to 100) with a single request.
+// A result of limit means limit or more.
+func (c *Client) CountTopicPRs(ctx context.Context, repo, branch string, limit int) (int, error) {
+	output, err := c.Get(ctx, pullsPath(repo, url.Values{
+		"state":    {"all"},
+		"base":     {branch},
+		"per_page": {strconv.Itoa(limit)},
+	}))
+	if err != nil {
+		return 0, err
+	}
+
+	var prs []json.RawMessage
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return 0, fmt.Errorf("failed to decode pull requests: %w", err)
+	}
+	return len(prs), nil
+}
+
+// FindOpenPR returns the open pull request whose head is branch in repo, or nil when there is none
+func (c *Client) FindOpenPR(ctx context.Context, repo, branch string) (*PullRequest, error) {
+	owner, _, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository: %s", repo)
+	}
+
+	output, err := c.Get(ctx, pullsPath(repo, url.Values{
+		"state":    {"open"},
+		"head":     {owner + ":" + branch},
+		"per_page": {"1"},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var prs []PullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to decode pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return &prs[0], nil
+}
+
+// BranchExists reports whether branch exists in repo on GitHub, which also
+// finds branches that were never fetched into the local checkout
+func (c *Client) BranchExists(ctx context.Context, repo, branch string) (bool, error) {
+	resp, err := c.Transport.Get(ctx, fmt.Sprintf("/repos/%s/branches/%s", repo, url.PathEscape(branch)), nil)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return false, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return false, &APIError{StatusCode: resp.StatusCode, Message: apiErrorMessage(resp.Body)}
+	}
+	return true, nil
+}
+
+// pullsPath returns the path of the pulls endpoint of repo with query encoded,
+// so branch names containing characters such as +, # or & are sent as is
+func pullsPath(repo string, query url.Values) string {
+	return fmt.Sprintf("/repos/%s/pulls?%s", repo, query.Encode())
+}
+
+// apiErrorMessage extracts the "message" field of a GitHub API error body
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	return strings.TrimSpace(string(body))
+}
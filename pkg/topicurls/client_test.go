@@ -0,0 +1,217 @@
+package topicurls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedTransport serves numbered pull requests split into API-sized pages
+type pagedTransport struct {
+	total    int
+	status   int
+	requests []string
+}
+
+func (p *pagedTransport) Get(ctx context.Context, path string, header http.Header) (*Response, error) {
+	p.requests = append(p.requests, path)
+	if p.status != 0 {
+		return &Response{StatusCode: p.status, Body: []byte(`{"message":"Not Found"}`)}, nil
+	}
+
+	// Requests without a page parameter get the first page
+	page := 1
+	if _, query, ok := strings.Cut(path, "&page="); ok {
+		fmt.Sscanf(query, "%d", &page)
+	}
+
+	var prs []map[string]any
+	for n := (page-1)*pullRequestsPerPage + 1; n <= min(page*pullRequestsPerPage, p.total); n++ {
+		pr := map[string]any{"number": n}
+		if n%2 == 0 {
+			pr["merged_at"] = "2025-09-01T00:00:00Z"
+		}
+		prs = append(prs, pr)
+	}
+	body, err := json.Marshal(prs)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{StatusCode: http.StatusOK, Body: body}, nil
+}
+
+func TestListTopicPRs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		total            int
+		opts             *ListOptions
+		expectedCount    int
+		expectedRequests int
+		expectedState    string
+	}{
+		{name: "Single page", total: 3, expectedCount: 3, expectedRequests: 1, expectedState: "state=all"},
+		{name: "Full page requests the next one", total: 100, expectedCount: 100, expectedRequests: 2, expectedState: "state=all"},
+		{name: "Several pages", total: 250, expectedCount: 250, expectedRequests: 3, expectedState: "state=all"},
+		{name: "Merged only", total: 5, opts: &ListOptions{MergedOnly: true}, expectedCount: 2, expectedRequests: 1, expectedState: "state=all"},
+		{name: "State filter", total: 1, opts: &ListOptions{State: "open"}, expectedCount: 1, expectedRequests: 1, expectedState: "state=open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: A transport serving tt.total pull requests
+			transport := &pagedTransport{total: tt.total}
+
+			// Act: List the pull requests into release/next
+			prs, err := NewClient(transport).ListTopicPRs(context.Background(), "owner/repo", "release/next", tt.opts)
+
+			// Assert: Every page was read once, oldest first
+			require.NoError(t, err)
+			assert.Len(t, prs, tt.expectedCount)
+			assert.Len(t, transport.requests, tt.expectedRequests)
+			assert.Equal(t, "/repos/owner/repo/pulls?base=release%2Fnext&direction=asc&page=1&per_page=100&sort=created&"+tt.expectedState, transport.requests[0])
+		})
+	}
+}
+
+func TestListTopicPRsEscapesBranch(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: A branch name with characters that are special in a query
+	transport := &staticTransport{body: `[]`}
+
+	// Act: List the pull requests into it
+	_, err := NewClient(transport).ListTopicPRs(context.Background(), "owner/repo", "fix/c++#1&more", nil)
+
+	// Assert: The whole name is sent as the base
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/repos/owner/repo/pulls?base=fix%2Fc%2B%2B%231%26more&direction=asc&page=1&per_page=100&sort=created&state=all"}, transport.requests)
+}
+
+func TestCountTopicPRs(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: More pull requests than the limit
+	transport := &pagedTransport{total: 250}
+
+	// Act: Count the pull requests into release/next
+	count, err := NewClient(transport).CountTopicPRs(context.Background(), "owner/repo", "release/next", pullRequestsPerPage)
+
+	// Assert: One request, capped at the limit
+	require.NoError(t, err)
+	assert.Equal(t, pullRequestsPerPage, count)
+	assert.Equal(t, []string{"/repos/owner/repo/pulls?base=release%2Fnext&per_page=100&state=all"}, transport.requests)
+}
+
+func TestClientGetError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient(&pagedTransport{status: http.StatusNotFound}).Get(context.Background(), "/repos/owner/missing")
+
+	assert.EqualError(t, err, "gh api error: HTTP 404: Not Found")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+// staticTransport answers every request with the same body
+type staticTransport struct {
+	body     string
+	requests []string
+}
+
+func (s *staticTransport) Get(ctx context.Context, path string, header http.Header) (*Response, error) {
+	s.requests = append(s.requests, path)
+	return &Response{StatusCode: http.StatusOK, Body: []byte(s.body)}, nil
+}
+
+func TestFindOpenPR(t *testing.T) {
+	t.Parallel()
+
+	// An open pull request from the branch
+	transport := &staticTransport{body: `[{"number":42,"base":{"ref":"release/next"},"head":{"ref":"feature/x"}}]`}
+	pr, err := NewClient(transport).FindOpenPR(context.Background(), "owner/repo", "feature/x")
+	require.NoError(t, err)
+	require.NotNil(t, pr)
+	assert.Equal(t, 42, pr.Number)
+	assert.Equal(t, "release/next", pr.Base.Ref)
+	assert.Equal(t, []string{"/repos/owner/repo/pulls?head=owner%3Afeature%2Fx&per_page=1&state=open"}, transport.requests)
+
+	// No pull request
+	pr, err = NewClient(&staticTransport{body: `[]`}).FindOpenPR(context.Background(), "owner/repo", "feature/x")
+	assert.NoError(t, err)
+	assert.Nil(t, pr)
+
+	// Invalid repository
+	_, err = NewClient(&staticTransport{}).FindOpenPR(context.Background(), "repo", "feature/x")
+	assert.Error(t, err)
+}
+
+// statusTransport answers every request with an empty body and a fixed status
+type statusTransport struct {
+	status   int
+	requests []string
+}
+
+func (s *statusTransport) Get(ctx context.Context, path string, header http.Header) (*Response, error) {
+	s.requests = append(s.requests, path)
+	return &Response{StatusCode: s.status, Body: []byte(`{"message":"status"}`)}, nil
+}
+
+func TestClientBranchExists(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		status        int
+		expected      bool
+		expectedError bool
+	}{
+		{name: "Existing branch", status: http.StatusOK, expected: true},
+		{name: "Missing branch", status: http.StatusNotFound, expected: false},
+		{name: "API failure", status: http.StatusInternalServerError, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: The branches endpoint answers with tt.status
+			transport := &statusTransport{status: tt.status}
+
+			// Act: Look the branch up on GitHub
+			exists, err := NewClient(transport).BranchExists(context.Background(), "owner/repo", "release/next")
+
+			// Assert: Only a 404 means the branch is missing
+			assert.Equal(t, []string{"/repos/owner/repo/branches/release%2Fnext"}, transport.requests)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, exists)
+		})
+	}
+}
+
+func ExampleClient_ListTopicPRs() {
+	client := NewClient(NewGHTransport())
+
+	prs, err := client.ListTopicPRs(context.Background(), "owner/repo", "release/next", &ListOptions{MergedOnly: true})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, pr := range prs {
+		fmt.Printf("#%d %s\n", pr.Number, pr.HTMLURL)
+	}
+}
@@ -0,0 +1,10 @@
+// Package topicurls lists the GitHub pull requests targeting a topic branch.
+//
+// It is the library behind the gh topic-urls CLI and can be embedded in other
+// tools: a Client fetches pull requests through a Transport (by default the gh
+// CLI, which handles authentication), and GitRepo implementations resolve the
+// repository and branch of a local checkout.
+//
+//	client := topicurls.NewClient(topicurls.NewGHTransport())
+//	prs, err := client.ListTopicPRs(ctx, "owner/repo", "release/next", nil)
+package topicurls
@@ -0,0 +1,348 @@
+package topicurls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitRepo provides the git operations used to resolve repositories and branches
+type GitRepo interface {
+	// RemoteURL returns the fetch URL of the named remote
+	RemoteURL(ctx context.Context, remote string) (string, error)
+	// CurrentBranch returns the checked out branch
+	CurrentBranch(ctx context.Context) (string, error)
+	// BranchExists reports whether the branch exists locally or on origin
+	BranchExists(ctx context.Context, name string) (bool, error)
+	// Branches lists local and remote branches, most recently committed first.
+	// A branch present locally and on remotes is listed once, as the local branch.
+	Branches(ctx context.Context) ([]Branch, error)
+	// RefExists reports whether ref resolves to a commit
+	RefExists(ctx context.Context, ref string) (bool, error)
+	// Commits lists the commit SHAs of a "from..to" range, oldest first
+	Commits(ctx context.Context, rangeSpec string) ([]string, error)
+	// NameRev names rev after a local or origin branch like git name-rev,
+	// e.g. "main" at the tip or "main~2" behind it
+	NameRev(ctx context.Context, rev string) (string, error)
+	// BranchesContaining lists the local and origin branches whose history contains rev
+	BranchesContaining(ctx context.Context, rev string) ([]string, error)
+}
+
+// ErrDetachedHead is returned by GitRepo.CurrentBranch when no branch is checked out
+var ErrDetachedHead = errors.New("could not determine current branch: HEAD is detached")
+
+// BranchNotFoundError is returned by ResolveBranch for a branch missing from the checkout
+type BranchNotFoundError struct {
+	Branch string
+	// Suggestions are known branches with similar names, closest first
+	Suggestions []string
+}
+
+func (e *BranchNotFoundError) Error() string {
+	msg := fmt.Sprintf("branch '%s' does not exist", e.Branch)
+	switch len(e.Suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s; did you mean %s?", msg, e.Suggestions[0])
+	default:
+		last := len(e.Suggestions) - 1
+		return fmt.Sprintf("%s; did you mean %s or %s?", msg, strings.Join(e.Suggestions[:last], ", "), e.Suggestions[last])
+	}
+}
+
+// ResolveBranch returns branch after checking that it exists, or the branch
+// found by DetectBranch when branch is empty. A missing branch is reported as a
+// *BranchNotFoundError suggesting the listed branches with similar names.
+func ResolveBranch(ctx context.Context, repo GitRepo, branch string, getenv func(string) string) (string, error) {
+	if branch == "" {
+		return DetectBranch(ctx, repo, getenv)
+	}
+
+	exists, err := repo.BranchExists(ctx, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to check branch existence: %w", err)
+	}
+	if !exists {
+		notFound := &BranchNotFoundError{Branch: branch}
+		if branches, err := repo.Branches(ctx); err == nil {
+			notFound.Suggestions = SuggestBranches(branch, BranchNames(branches))
+		}
+		return "", notFound
+	}
+
+	return branch, nil
+}
+
+// DetectBranch returns the checked out branch. When HEAD is detached, as in most
+// CI checkouts and in submodules, the branch is taken from the CI environment
+// (read through getenv), then from git name-rev when HEAD is a branch tip, and
+// finally from the only branch containing HEAD.
+func DetectBranch(ctx context.Context, repo GitRepo, getenv func(string) string) (string, error) {
+	branch, err := repo.CurrentBranch(ctx)
+	if !errors.Is(err, ErrDetachedHead) {
+		return branch, err
+	}
+
+	if branch := ciBranch(getenv); branch != "" {
+		return branch, nil
+	}
+
+	// name-rev describes commits behind a tip as "branch~n"; only a tip names the branch
+	if name, nameErr := repo.NameRev(ctx, "HEAD"); nameErr == nil && name != "" && !strings.ContainsAny(name, "~^") {
+		return name, nil
+	}
+
+	branches, containsErr := repo.BranchesContaining(ctx, "HEAD")
+	if containsErr != nil {
+		return "", err
+	}
+	switch len(branches) {
+	case 0:
+		return "", err
+	case 1:
+		return branches[0], nil
+	default:
+		return "", fmt.Errorf("%w and contained in %d branches (%s); pass the branch name", err, len(branches), strings.Join(branches, ", "))
+	}
+}
+
+// ciBranch returns the branch being built according to the CI environment
+func ciBranch(getenv func(string) string) string {
+	// GitHub Actions pull_request events check out a merge commit; the head ref is the topic branch
+	if branch := getenv("GITHUB_HEAD_REF"); branch != "" {
+		return branch
+	}
+	// Other GitHub Actions events; pull request refs ("42/merge") and tags are not branches
+	if branch := getenv("GITHUB_REF_NAME"); branch != "" && getenv("GITHUB_REF_TYPE") != "tag" && !strings.HasSuffix(branch, "/merge") {
+		return branch
+	}
+	// GitLab CI sets CI_COMMIT_REF_NAME to the tag name for tag pipelines
+	if branch := getenv("CI_COMMIT_REF_NAME"); branch != "" && getenv("CI_COMMIT_TAG") == "" {
+		return branch
+	}
+	return ""
+}
+
+// shortBranchName turns a full local or origin branch ref, or a name printed by
+// git name-rev, into the branch name; other refs yield an empty string
+func shortBranchName(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/remotes/origin/", "remotes/origin/", "origin/"} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			if name == "HEAD" {
+				return ""
+			}
+			return name
+		}
+	}
+	if strings.HasPrefix(ref, "refs/") || strings.HasPrefix(ref, "remotes/") {
+		return ""
+	}
+	return ref
+}
+
+// RepoFromRemote returns the owner/name of the GitHub repository behind a remote
+func RepoFromRemote(ctx context.Context, repo GitRepo, remote string) (string, error) {
+	remoteURL, err := repo.RemoteURL(ctx, remote)
+	if err != nil {
+		return "", err
+	}
+
+	return ParseRepoFromURL(remoteURL)
+}
+
+// ParseRepoFromURL extracts owner/repo from Git remote URL
+func ParseRepoFromURL(remoteURL string) (string, error) {
+	// Handle SSH URL format: git@github.com:owner/repo.git
+	if strings.HasPrefix(remoteURL, "git@") {
+		parts := strings.Split(remoteURL, ":")
+		if len(parts) >= 2 {
+			repoPath := parts[len(parts)-1]
+			repoPath = strings.TrimSuffix(repoPath, ".git")
+			// Validate that repo path is not empty
+			if repoPath != "" {
+				return repoPath, nil
+			}
+		}
+	}
+
+	// Handle HTTPS URL format: https://github.com/owner/repo.git
+	if strings.HasPrefix(remoteURL, "https://") {
+		parts := strings.Split(remoteURL, "/")
+		if len(parts) >= 5 {
+			owner := parts[len(parts)-2]
+			repo := strings.TrimSuffix(parts[len(parts)-1], ".git")
+			// Validate that owner and repo are not empty
+			if owner != "" && repo != "" {
+				return fmt.Sprintf("%s/%s", owner, repo), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unsupported remote URL format: %s", remoteURL)
+}
+
+// SplitRange splits "from..to" (or "from...to") into its refs, defaulting to HEAD
+func SplitRange(rangeSpec string) (string, string, error) {
+	from, to, ok := strings.Cut(rangeSpec, "..")
+	if !ok {
+		return "", "", fmt.Errorf("invalid range '%s': expected <from>..<to>", rangeSpec)
+	}
+
+	// "from...to" is treated like "from..to": merged PRs only exist on one side
+	to = strings.TrimPrefix(to, ".")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+
+	if from == "" {
+		return "", "", fmt.Errorf("invalid range '%s': missing <from> ref", rangeSpec)
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	return from, to, nil
+}
+
+// ExecGitRepo implements GitRepo by running the git CLI in Dir
+type ExecGitRepo struct {
+	// Exec starts the git process
+	Exec CommandRunner
+	// Dir is the checkout to run git in; empty means the working directory
+	Dir string
+}
+
+// NewExecGitRepo returns an ExecGitRepo running the git binary from PATH in dir
+func NewExecGitRepo(dir string) ExecGitRepo {
+	return ExecGitRepo{Exec: exec.CommandContext, Dir: dir}
+}
+
+func (r ExecGitRepo) RemoteURL(ctx context.Context, remote string) (string, error) {
+	cmd := r.Exec(ctx, "git", "remote", "get-url", remote)
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote URL: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (r ExecGitRepo) CurrentBranch(ctx context.Context) (string, error) {
+	cmd := r.Exec(ctx, "git", "branch", "--show-current")
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	// --show-current prints nothing when HEAD is detached
+	branch := strings.TrimSpace(string(output))
+	if branch == "" {
+		return "", ErrDetachedHead
+	}
+
+	return branch, nil
+}
+
+func (r ExecGitRepo) BranchExists(ctx context.Context, branchName string) (bool, error) {
+	cmd := r.Exec(ctx, "git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/heads/%s", branchName))
+	cmd.Dir = r.Dir
+	cmd.Stderr = nil // Suppress error output for cleaner check
+
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+
+	// Check if it's a remote branch
+	cmd = r.Exec(ctx, "git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/remotes/origin/%s", branchName))
+	cmd.Dir = r.Dir
+	cmd.Stderr = nil
+
+	err = cmd.Run()
+	return err == nil, nil
+}
+
+func (r ExecGitRepo) RefExists(ctx context.Context, ref string) (bool, error) {
+	exists, err := r.BranchExists(ctx, ref)
+	if err != nil || exists {
+		return exists, err
+	}
+
+	cmd := r.Exec(ctx, "git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = r.Dir
+	cmd.Stderr = nil
+
+	err = cmd.Run()
+	return err == nil, nil
+}
+
+func (r ExecGitRepo) Branches(ctx context.Context) ([]Branch, error) {
+	cmd := r.Exec(ctx, "git", "for-each-ref", "--sort=-committerdate", "--format="+forEachRefFormat, "refs/heads", "refs/remotes")
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	return parseForEachRef(string(output)), nil
+}
+
+func (r ExecGitRepo) Commits(ctx context.Context, rangeSpec string) ([]string, error) {
+	cmd := r.Exec(ctx, "git", "log", "--format=%H", "--reverse", rangeSpec, "--")
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits in '%s': %w", rangeSpec, err)
+	}
+
+	var commits []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if sha := strings.TrimSpace(line); sha != "" {
+			commits = append(commits, sha)
+		}
+	}
+
+	return commits, nil
+}
+
+func (r ExecGitRepo) NameRev(ctx context.Context, rev string) (string, error) {
+	cmd := r.Exec(ctx, "git", "name-rev", "--name-only", "--no-undefined",
+		"--refs=refs/heads/*", "--refs=refs/remotes/origin/*", "--exclude=refs/remotes/origin/HEAD", rev)
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to name %s: %w", rev, err)
+	}
+
+	return shortBranchName(strings.TrimSpace(string(output))), nil
+}
+
+func (r ExecGitRepo) BranchesContaining(ctx context.Context, rev string) ([]string, error) {
+	cmd := r.Exec(ctx, "git", "branch", "-a", "--contains", rev, "--format=%(refname)")
+	cmd.Dir = r.Dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches containing %s: %w", rev, err)
+	}
+
+	var branches []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		// Skips the "(HEAD detached at ...)" entry
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "refs/") {
+			continue
+		}
+		name := shortBranchName(line)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		branches = append(branches, name)
+	}
+
+	return branches, nil
+}
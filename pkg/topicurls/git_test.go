@@ -0,0 +1,330 @@
+package topicurls
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRepoFromURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		remoteURL    string
+		expectedRepo string
+		expectError  bool
+	}{
+		{
+			name:         "SSH URL with .git",
+			remoteURL:    "git@github.com:owner/repo.git",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "SSH URL without .git",
+			remoteURL:    "git@github.com:owner/repo",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "HTTPS URL with .git",
+			remoteURL:    "https://github.com/owner/repo.git",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "HTTPS URL without .git",
+			remoteURL:    "https://github.com/owner/repo",
+			expectedRepo: "owner/repo",
+		},
+		{
+			name:         "SSH URL with nested path",
+			remoteURL:    "git@github.com:organization/project-name.git",
+			expectedRepo: "organization/project-name",
+		},
+		{
+			name:         "HTTPS URL with nested path",
+			remoteURL:    "https://github.com/my-org/my-awesome-project.git",
+			expectedRepo: "my-org/my-awesome-project",
+		},
+		{
+			name:        "Unsupported URL format",
+			remoteURL:   "ftp://example.com/repo.git",
+			expectError: true,
+		},
+		{
+			name:        "Empty URL",
+			remoteURL:   "",
+			expectError: true,
+		},
+		{
+			name:        "Invalid SSH format",
+			remoteURL:   "git@github.com",
+			expectError: true,
+		},
+		{
+			name:        "Invalid HTTPS format",
+			remoteURL:   "https://github.com/",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Given: Remote URL
+			remoteURL := tt.remoteURL
+
+			// When: Parse URL
+			result, err := ParseRepoFromURL(remoteURL)
+
+			// Then: Verify results
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRepo, result)
+			}
+		})
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		rangeSpec    string
+		expectedFrom string
+		expectedTo   string
+		expectError  bool
+	}{
+		{name: "Tags", rangeSpec: "v1.2.0..v1.3.0", expectedFrom: "v1.2.0", expectedTo: "v1.3.0"},
+		{name: "Three dots", rangeSpec: "v1.2.0...v1.3.0", expectedFrom: "v1.2.0", expectedTo: "v1.3.0"},
+		{name: "Branches with slashes", rangeSpec: "main..release/next", expectedFrom: "main", expectedTo: "release/next"},
+		{name: "Missing to defaults to HEAD", rangeSpec: "v1.2.0..", expectedFrom: "v1.2.0", expectedTo: "HEAD"},
+		{name: "Missing from", rangeSpec: "..v1.3.0", expectError: true},
+		{name: "No range separator", rangeSpec: "v1.2.0", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Split range
+			from, to, err := SplitRange(tt.rangeSpec)
+
+			// Assert: Verify refs
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedFrom, from)
+				assert.Equal(t, tt.expectedTo, to)
+			}
+		})
+	}
+}
+
+// fakeGitRepo answers GitRepo calls from fixed data
+type fakeGitRepo struct {
+	GitRepo
+	current    string
+	detached   bool
+	branches   map[string]bool
+	nameRev    string
+	containing []string
+}
+
+func (f fakeGitRepo) CurrentBranch(ctx context.Context) (string, error) {
+	if f.detached {
+		return "", ErrDetachedHead
+	}
+	if f.current == "" {
+		return "", fmt.Errorf("failed to get current branch: not a git repository")
+	}
+	return f.current, nil
+}
+
+func (f fakeGitRepo) NameRev(ctx context.Context, rev string) (string, error) {
+	if f.nameRev == "" {
+		return "", fmt.Errorf("cannot describe %s", rev)
+	}
+	return f.nameRev, nil
+}
+
+func (f fakeGitRepo) BranchesContaining(ctx context.Context, rev string) ([]string, error) {
+	return f.containing, nil
+}
+
+func (f fakeGitRepo) BranchExists(ctx context.Context, name string) (bool, error) {
+	return f.branches[name], nil
+}
+
+func (f fakeGitRepo) Branches(ctx context.Context) ([]Branch, error) {
+	var branches []Branch
+	for name := range f.branches {
+		branches = append(branches, Branch{Name: name})
+	}
+	return branches, nil
+}
+
+func TestResolveBranch(t *testing.T) {
+	t.Parallel()
+
+	repo := fakeGitRepo{current: "feature/x", branches: map[string]bool{"release/next": true}}
+
+	tests := []struct {
+		name        string
+		repo        fakeGitRepo
+		branch      string
+		expected    string
+		expectError bool
+	}{
+		{name: "Empty branch uses the current one", repo: repo, expected: "feature/x"},
+		{name: "Existing branch", repo: repo, branch: "release/next", expected: "release/next"},
+		{name: "Missing branch", repo: repo, branch: "release/nxet", expectError: true},
+		{name: "No current branch", repo: fakeGitRepo{}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Resolve the branch
+			branch, err := ResolveBranch(context.Background(), tt.repo, tt.branch, noEnv)
+
+			// Assert: Verify the branch or error
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, branch)
+		})
+	}
+
+	// A missing branch is reported with its name and similar branches
+	_, err := ResolveBranch(context.Background(), repo, "release/nxet", noEnv)
+	var notFound *BranchNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "release/nxet", notFound.Branch)
+	assert.EqualError(t, err, "branch 'release/nxet' does not exist; did you mean release/next?")
+}
+
+func TestBranchNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, &BranchNotFoundError{Branch: "x"}, "branch 'x' does not exist")
+	assert.EqualError(t, &BranchNotFoundError{Branch: "mian", Suggestions: []string{"main", "man", "mina"}},
+		"branch 'mian' does not exist; did you mean main, man or mina?")
+}
+
+// noEnv is a getenv with no variables set
+func noEnv(string) string { return "" }
+
+func TestDetectBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		repo        fakeGitRepo
+		env         map[string]string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Checked out branch wins over the environment",
+			repo:     fakeGitRepo{current: "feature/x"},
+			env:      map[string]string{"GITHUB_HEAD_REF": "other"},
+			expected: "feature/x",
+		},
+		{
+			name:        "Errors other than a detached HEAD are returned",
+			repo:        fakeGitRepo{},
+			env:         map[string]string{"GITHUB_HEAD_REF": "feature/x"},
+			expectError: true,
+		},
+		{
+			name:     "GitHub Actions pull request",
+			repo:     fakeGitRepo{detached: true},
+			env:      map[string]string{"GITHUB_HEAD_REF": "feature/x", "GITHUB_REF_NAME": "42/merge"},
+			expected: "feature/x",
+		},
+		{
+			name:     "GitHub Actions push",
+			repo:     fakeGitRepo{detached: true},
+			env:      map[string]string{"GITHUB_REF_NAME": "release/next", "GITHUB_REF_TYPE": "branch"},
+			expected: "release/next",
+		},
+		{
+			name:     "GitHub Actions tag is not a branch",
+			repo:     fakeGitRepo{detached: true, nameRev: "main"},
+			env:      map[string]string{"GITHUB_REF_NAME": "v1.2.0", "GITHUB_REF_TYPE": "tag"},
+			expected: "main",
+		},
+		{
+			name:     "GitLab CI",
+			repo:     fakeGitRepo{detached: true},
+			env:      map[string]string{"CI_COMMIT_REF_NAME": "release/next"},
+			expected: "release/next",
+		},
+		{
+			name:     "Branch tip from name-rev",
+			repo:     fakeGitRepo{detached: true, nameRev: "release/next", containing: []string{"main", "release/next"}},
+			expected: "release/next",
+		},
+		{
+			name:     "Behind a tip, the only branch containing HEAD",
+			repo:     fakeGitRepo{detached: true, nameRev: "release/next~2", containing: []string{"release/next"}},
+			expected: "release/next",
+		},
+		{
+			name:        "Several branches contain HEAD",
+			repo:        fakeGitRepo{detached: true, nameRev: "main~3", containing: []string{"main", "release/next"}},
+			expectError: true,
+		},
+		{
+			name:        "No branch contains HEAD",
+			repo:        fakeGitRepo{detached: true},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getenv := func(key string) string { return tt.env[key] }
+
+			// Act: Detect the branch
+			branch, err := DetectBranch(context.Background(), tt.repo, getenv)
+
+			// Assert: Verify the branch or error
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, branch)
+		})
+	}
+}
+
+func TestShortBranchName(t *testing.T) {
+	t.Parallel()
+
+	for ref, expected := range map[string]string{
+		"refs/heads/feature/x":        "feature/x",
+		"refs/remotes/origin/main":    "main",
+		"refs/remotes/origin/HEAD":    "",
+		"refs/remotes/upstream/main":  "",
+		"refs/tags/v1.0.0":            "",
+		"remotes/origin/release/next": "release/next",
+		"release/next":                "release/next",
+	} {
+		assert.Equal(t, expected, shortBranchName(ref), ref)
+	}
+}
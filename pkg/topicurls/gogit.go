@@ -0,0 +1,311 @@
+package topicurls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// goGitRepo implements GitRepo in pure Go, so no git binary is needed
+type goGitRepo struct {
+	repo *git.Repository
+}
+
+// OpenGoGitRepo opens the repository containing dir, including linked worktrees.
+// An empty dir means the working directory.
+func OpenGoGitRepo(dir string) (GitRepo, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	return goGitRepo{repo: repo}, nil
+}
+
+func (r goGitRepo) RemoteURL(ctx context.Context, name string) (string, error) {
+	remote, err := r.repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote URL: %w", err)
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("failed to get remote URL: remote '%s' has no URL", name)
+	}
+
+	return urls[0], nil
+}
+
+func (r goGitRepo) CurrentBranch(ctx context.Context) (string, error) {
+	// HEAD is read without resolving it so unborn branches are reported too
+	head, err := r.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", ErrDetachedHead
+	}
+
+	return head.Target().Short(), nil
+}
+
+func (r goGitRepo) BranchExists(ctx context.Context, branchName string) (bool, error) {
+	for _, name := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(branchName),
+		plumbing.NewRemoteReferenceName("origin", branchName),
+	} {
+		if _, err := r.repo.Reference(name, false); err == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r goGitRepo) RefExists(ctx context.Context, ref string) (bool, error) {
+	_, err := r.repo.ResolveRevision(plumbing.Revision(ref))
+	return err == nil, nil
+}
+
+func (r goGitRepo) Branches(ctx context.Context) ([]Branch, error) {
+	refs, err := r.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	var branches []Branch
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		name, remote, ok := splitBranchRef(ref.Name().String())
+		if !ok {
+			return nil
+		}
+
+		branch := Branch{Name: name, Remote: remote}
+		if upstream, found := cfg.Branches[name]; remote == "" && found && upstream.Remote != "" && upstream.Merge.IsBranch() {
+			branch.Upstream = upstream.Remote + "/" + upstream.Merge.Short()
+		}
+		if commit, err := r.repo.CommitObject(ref.Hash()); err == nil {
+			branch.CommitDate = commit.Committer.When
+			branch.Author = commit.Author.Name
+		}
+		branches = append(branches, branch)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	return dedupeBranches(branches), nil
+}
+
+func (r goGitRepo) Commits(ctx context.Context, rangeSpec string) ([]string, error) {
+	from, to, err := SplitRange(rangeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	fromCommit, err := r.resolveCommit(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits in '%s': %w", rangeSpec, err)
+	}
+	toCommit, err := r.resolveCommit(to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits in '%s': %w", rangeSpec, err)
+	}
+
+	excluded, err := ancestors(ctx, fromCommit, nil)
+	if err != nil {
+		return nil, err
+	}
+	included, err := ancestors(ctx, toCommit, excluded)
+	if err != nil {
+		return nil, err
+	}
+
+	// "from...to" is the symmetric difference: also include commits only in from
+	if strings.Contains(rangeSpec, "...") {
+		toAncestors, err := ancestors(ctx, toCommit, nil)
+		if err != nil {
+			return nil, err
+		}
+		fromOnly, err := ancestors(ctx, fromCommit, toAncestors)
+		if err != nil {
+			return nil, err
+		}
+		for hash, commit := range fromOnly {
+			included[hash] = commit
+		}
+	}
+
+	commits := make([]*object.Commit, 0, len(included))
+	for _, commit := range included {
+		commits = append(commits, commit)
+	}
+	sort.Slice(commits, func(i, j int) bool {
+		if commits[i].Committer.When.Equal(commits[j].Committer.When) {
+			return commits[i].Hash.String() < commits[j].Hash.String()
+		}
+		return commits[i].Committer.When.Before(commits[j].Committer.When)
+	})
+
+	shas := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		shas = append(shas, commit.Hash.String())
+	}
+	return shas, nil
+}
+
+func (r goGitRepo) NameRev(ctx context.Context, rev string) (string, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("failed to name %s: %w", rev, err)
+	}
+
+	// Only branch tips are named; unlike git name-rev, commits behind a tip are not
+	branches, err := r.branchTips()
+	if err != nil {
+		return "", err
+	}
+	for _, b := range branches {
+		if b.hash == *hash {
+			return b.name, nil
+		}
+	}
+	return "", fmt.Errorf("failed to name %s: no branch points at it", rev)
+}
+
+func (r goGitRepo) BranchesContaining(ctx context.Context, rev string) ([]string, error) {
+	target, err := r.resolveCommit(rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches containing %s: %w", rev, err)
+	}
+
+	branches, err := r.branchTips()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, b := range branches {
+		tip, err := r.repo.CommitObject(b.hash)
+		if err != nil {
+			continue
+		}
+		contains := tip.Hash == target.Hash
+		if !contains {
+			if contains, err = target.IsAncestor(tip); err != nil {
+				return nil, fmt.Errorf("failed to get branches containing %s: %w", rev, err)
+			}
+		}
+		if contains {
+			names = append(names, b.name)
+		}
+	}
+	return names, nil
+}
+
+// branchTip is a local or origin branch and the commit it points at
+type branchTip struct {
+	name string
+	hash plumbing.Hash
+}
+
+// branchTips lists local branches, then origin branches not also present locally, by name
+func (r goGitRepo) branchTips() ([]branchTip, error) {
+	refs, err := r.repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	var local, remote []branchTip
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		name := shortBranchName(ref.Name().String())
+		switch {
+		case name == "":
+		case ref.Name().IsBranch():
+			local = append(local, branchTip{name: name, hash: ref.Hash()})
+		case ref.Name().IsRemote():
+			remote = append(remote, branchTip{name: name, hash: ref.Hash()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	byName := func(tips []branchTip) {
+		sort.Slice(tips, func(i, j int) bool { return tips[i].name < tips[j].name })
+	}
+	byName(local)
+	byName(remote)
+
+	seen := make(map[string]bool, len(local))
+	for _, b := range local {
+		seen[b.name] = true
+	}
+	for _, b := range remote {
+		if !seen[b.name] {
+			local = append(local, b)
+		}
+	}
+	return local, nil
+}
+
+func (r goGitRepo) resolveCommit(ref string) (*object.Commit, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision '%s': %w", ref, err)
+	}
+
+	return r.repo.CommitObject(*hash)
+}
+
+// ancestors collects start and its ancestors, not walking past commits in stop
+func ancestors(ctx context.Context, start *object.Commit, stop map[plumbing.Hash]*object.Commit) (map[plumbing.Hash]*object.Commit, error) {
+	seen := make(map[plumbing.Hash]bool, len(stop))
+	for hash := range stop {
+		seen[hash] = true
+	}
+
+	result := make(map[plumbing.Hash]*object.Commit)
+	iter := object.NewCommitPreorderIter(start, seen, nil)
+	defer iter.Close()
+
+	err := iter.ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result[commit.Hash] = commit
+		return nil
+	})
+	if err != nil && !errors.Is(err, storer.ErrStop) {
+		return nil, fmt.Errorf("failed to walk commits: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,266 @@
+package topicurls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepo is an in-memory repository for exercising the go-git backend
+type memoryRepo struct {
+	t    *testing.T
+	repo *git.Repository
+	when time.Time
+}
+
+func newMemoryRepo(t *testing.T) *memoryRepo {
+	t.Helper()
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:owner/repo.git"}})
+	require.NoError(t, err)
+
+	return &memoryRepo{t: t, repo: repo, when: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// commit creates a commit on the checked out branch, one hour after the previous one
+func (m *memoryRepo) commit(message string) plumbing.Hash {
+	m.t.Helper()
+
+	wt, err := m.repo.Worktree()
+	require.NoError(m.t, err)
+
+	f, err := wt.Filesystem.Create(message + ".txt")
+	require.NoError(m.t, err)
+	_, err = f.Write([]byte(message))
+	require.NoError(m.t, err)
+	require.NoError(m.t, f.Close())
+	_, err = wt.Add(message + ".txt")
+	require.NoError(m.t, err)
+
+	m.when = m.when.Add(time.Hour)
+	hash, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@example.com", When: m.when},
+	})
+	require.NoError(m.t, err)
+	return hash
+}
+
+// setRef points a reference at a commit
+func (m *memoryRepo) setRef(name plumbing.ReferenceName, hash plumbing.Hash) {
+	m.t.Helper()
+	require.NoError(m.t, m.repo.Storer.SetReference(plumbing.NewHashReference(name, hash)))
+}
+
+// checkout switches the worktree to a branch
+func (m *memoryRepo) checkout(branch string) {
+	m.t.Helper()
+
+	wt, err := m.repo.Worktree()
+	require.NoError(m.t, err)
+	require.NoError(m.t, wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch)}))
+}
+
+func TestGoGitRepo(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: master with a tag, a feature branch and a remote-only branch
+	m := newMemoryRepo(t)
+	first := m.commit("first")
+	_, err := m.repo.CreateTag("v1.0.0", first, nil)
+	require.NoError(t, err)
+	second := m.commit("second")
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "release/next"), first)
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "master"), second)
+	m.setRef(plumbing.NewBranchReferenceName("feature/x"), second)
+	m.checkout("feature/x")
+	third := m.commit("third")
+
+	repo := goGitRepo{repo: m.repo}
+	ctx := context.Background()
+
+	t.Run("RemoteURL", func(t *testing.T) {
+		url, err := repo.RemoteURL(ctx, "origin")
+		assert.NoError(t, err)
+		assert.Equal(t, "git@github.com:owner/repo.git", url)
+
+		_, err = repo.RemoteURL(ctx, "upstream")
+		assert.Error(t, err)
+	})
+
+	t.Run("CurrentBranch", func(t *testing.T) {
+		branch, err := repo.CurrentBranch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "feature/x", branch)
+	})
+
+	t.Run("BranchExists", func(t *testing.T) {
+		for name, expected := range map[string]bool{
+			"master":       true,
+			"feature/x":    true,
+			"release/next": true, // only on origin
+			"v1.0.0":       false,
+			"missing":      false,
+		} {
+			exists, err := repo.BranchExists(ctx, name)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, exists, name)
+		}
+	})
+
+	t.Run("RefExists", func(t *testing.T) {
+		for ref, expected := range map[string]bool{
+			"v1.0.0":       true,
+			"HEAD~1":       true,
+			first.String(): true,
+			"missing":      false,
+		} {
+			exists, err := repo.RefExists(ctx, ref)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, exists, ref)
+		}
+	})
+
+	t.Run("Branches", func(t *testing.T) {
+		branches, err := repo.Branches(ctx)
+		assert.NoError(t, err)
+		// Most recent first; origin/master collapses into master
+		assert.Equal(t, []string{"feature/x", "master", "release/next"}, BranchNames(branches))
+		assert.Equal(t, "", branches[1].Remote)
+		assert.Equal(t, "origin", branches[2].Remote)
+		assert.Equal(t, "Test", branches[0].Author)
+	})
+
+	t.Run("Commits", func(t *testing.T) {
+		commits, err := repo.Commits(ctx, "v1.0.0..HEAD")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{second.String(), third.String()}, commits)
+
+		commits, err = repo.Commits(ctx, "feature/x..master")
+		assert.NoError(t, err)
+		assert.Empty(t, commits)
+
+		_, err = repo.Commits(ctx, "missing..HEAD")
+		assert.Error(t, err)
+	})
+}
+
+func TestGoGitRepoBranches(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: master tracking origin/master, and branches only on origin and upstream
+	m := newMemoryRepo(t)
+	first := m.commit("first")
+	second := m.commit("second")
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "master"), second)
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "HEAD"), second)
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "release/next"), first)
+	m.setRef(plumbing.NewRemoteReferenceName("upstream", "release/next"), second)
+	m.setRef(plumbing.NewRemoteReferenceName("upstream", "x"), first)
+	cfg, err := m.repo.Config()
+	require.NoError(t, err)
+	cfg.Branches["master"] = &config.Branch{Name: "master", Remote: "origin", Merge: plumbing.NewBranchReferenceName("master")}
+	require.NoError(t, m.repo.SetConfig(cfg))
+
+	// Act: List the branches
+	branches, err := goGitRepo{repo: m.repo}.Branches(context.Background())
+
+	// Assert: One record per name, without the branches of other remotes
+	require.NoError(t, err)
+	assert.Equal(t, []Branch{
+		{Name: "master", Upstream: "origin/master", CommitDate: m.when, Author: "Test"},
+		{Name: "release/next", Remote: "origin", CommitDate: m.when.Add(-time.Hour), Author: "Test"},
+	}, normalizeDates(branches))
+}
+
+// normalizeDates converts commit dates to UTC so they compare with assert.Equal
+func normalizeDates(branches []Branch) []Branch {
+	for i := range branches {
+		branches[i].CommitDate = branches[i].CommitDate.UTC()
+	}
+	return branches
+}
+
+func TestGoGitRepoDetachedHead(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: master one commit ahead of release/next, which only exists on
+	// origin, and HEAD detached at release/next
+	m := newMemoryRepo(t)
+	first := m.commit("first")
+	second := m.commit("second")
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "release/next"), first)
+	m.setRef(plumbing.NewRemoteReferenceName("origin", "HEAD"), first)
+	m.setRef(plumbing.HEAD, first)
+
+	repo := goGitRepo{repo: m.repo}
+	ctx := context.Background()
+
+	// Act & Assert: No branch is checked out
+	_, err := repo.CurrentBranch(ctx)
+	assert.ErrorIs(t, err, ErrDetachedHead)
+
+	// HEAD is the tip of release/next and contained in both branches
+	name, err := repo.NameRev(ctx, "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, "release/next", name)
+	branches, err := repo.BranchesContaining(ctx, "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"master", "release/next"}, branches)
+
+	// The newer commit is only on master
+	name, err = repo.NameRev(ctx, second.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "master", name)
+	branches, err = repo.BranchesContaining(ctx, second.String())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"master"}, branches)
+}
+
+func TestGoGitRepoSymmetricRange(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Two branches diverging from a common commit
+	m := newMemoryRepo(t)
+	base := m.commit("base")
+	m.setRef(plumbing.NewBranchReferenceName("other"), base)
+	onMaster := m.commit("on-master")
+	m.checkout("other")
+	onOther := m.commit("on-other")
+
+	// Act: List the symmetric difference
+	commits, err := goGitRepo{repo: m.repo}.Commits(context.Background(), "master...other")
+
+	// Assert: Commits from both sides, oldest first
+	assert.NoError(t, err)
+	assert.Equal(t, []string{onMaster.String(), onOther.String()}, commits)
+}
+
+func TestOpenGoGitRepo(t *testing.T) {
+	t.Parallel()
+
+	// A directory without a repository fails
+	_, err := OpenGoGitRepo(t.TempDir())
+	assert.Error(t, err)
+
+	// A fresh repository is on its unborn default branch
+	dir := t.TempDir()
+	_, err = git.PlainInit(dir, false)
+	require.NoError(t, err)
+	repo, err := OpenGoGitRepo(dir)
+	assert.NoError(t, err)
+	branch, err := repo.CurrentBranch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "master", branch)
+}
@@ -0,0 +1,82 @@
+//go:build integration
+// +build integration
+
+package topicurls
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{
+		"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "protocol.file.allow=always",
+	}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+// openers returns the GitRepo implementations to check against a real checkout
+func openers() map[string]func(dir string) (GitRepo, error) {
+	return map[string]func(dir string) (GitRepo, error){
+		"exec":   func(dir string) (GitRepo, error) { return NewExecGitRepo(dir), nil },
+		"go-git": OpenGoGitRepo,
+	}
+}
+
+func TestIntegrationLinkedWorktreeAndSubmodule(t *testing.T) {
+	// Arrange: A repository with a linked worktree on feature/wt and a
+	// submodule whose HEAD is detached at the tip of its main branch
+	root := t.TempDir()
+	lib := filepath.Join(root, "lib")
+	app := filepath.Join(root, "app")
+	worktree := filepath.Join(root, "app-wt")
+
+	runGit(t, root, "init", "-q", "-b", "main", lib)
+	runGit(t, lib, "commit", "-q", "--allow-empty", "-m", "lib")
+
+	runGit(t, root, "init", "-q", "-b", "main", app)
+	runGit(t, app, "commit", "-q", "--allow-empty", "-m", "app")
+	runGit(t, app, "worktree", "add", "-q", "-b", "feature/wt", worktree)
+	runGit(t, app, "submodule", "add", "-q", lib, "lib")
+	runGit(t, filepath.Join(app, "lib"), "checkout", "-q", "--detach")
+
+	noEnv := func(string) string { return "" }
+	ctx := context.Background()
+
+	for name, open := range openers() {
+		t.Run(name, func(t *testing.T) {
+			// Act & Assert: The worktree reports its own branch
+			repo, err := open(worktree)
+			require.NoError(t, err)
+			branch, err := DetectBranch(ctx, repo, noEnv)
+			assert.NoError(t, err)
+			assert.Equal(t, "feature/wt", branch)
+
+			// Both branches of the worktrees are listed once, with their author
+			branches, err := repo.Branches(ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"main", "feature/wt"}, BranchNames(branches))
+			assert.Equal(t, "Test", branches[0].Author)
+			assert.False(t, branches[0].CommitDate.IsZero())
+
+			// The detached submodule resolves to the branch at its HEAD
+			repo, err = open(filepath.Join(app, "lib"))
+			require.NoError(t, err)
+			_, err = repo.CurrentBranch(ctx)
+			assert.ErrorIs(t, err, ErrDetachedHead)
+			branch, err = DetectBranch(ctx, repo, noEnv)
+			assert.NoError(t, err)
+			assert.Equal(t, "main", branch)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package topicurls
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// CommandRunner creates an external command, like exec.CommandContext
+type CommandRunner func(ctx context.Context, name string, arg ...string) *exec.Cmd
+
+// Response is the parsed result of a REST API request
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Transport performs GET requests against the GitHub REST API. Paths are
+// relative to the API root, e.g. "/repos/owner/repo/pulls".
+type Transport interface {
+	Get(ctx context.Context, path string, header http.Header) (*Response, error)
+}
+
+// GHTransport sends requests through the gh CLI, which takes care of authentication
+type GHTransport struct {
+	// Exec starts the gh process
+	Exec CommandRunner
+}
+
+// NewGHTransport returns a GHTransport running the gh binary from PATH
+func NewGHTransport() GHTransport {
+	return GHTransport{Exec: exec.CommandContext}
+}
+
+// Get runs "gh api -i" and parses the status line, headers and body it prints
+func (t GHTransport) Get(ctx context.Context, path string, header http.Header) (*Response, error) {
+	args := []string{"api", "-i",
+		"-H", "Accept: application/vnd.github+json",
+		"-H", "X-GitHub-Api-Version: 2022-11-28",
+	}
+	for name, values := range header {
+		for _, value := range values {
+			args = append(args, "-H", fmt.Sprintf("%s: %s", name, value))
+		}
+	}
+	args = append(args, path)
+
+	cmd := t.Exec(ctx, "gh", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// gh exits non-zero for any status above 299 (including 304 Not Modified)
+	// but still prints the response, so the output is parsed before the error
+	output, runErr := cmd.Output()
+	resp, err := ParseResponse(output)
+	if err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("gh api error: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ParseResponse parses the output of "gh api -i": a status line, headers, a blank line and the body
+func ParseResponse(output []byte) (*Response, error) {
+	reader := bufio.NewReader(bytes.NewReader(output))
+	tp := textproto.NewReader(reader)
+
+	statusLine, err := tp.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API response: %w", err)
+	}
+
+	proto, status, ok := strings.Cut(statusLine, " ")
+	if !ok || !strings.HasPrefix(proto, "HTTP/") {
+		return nil, fmt.Errorf("malformed API response status line: %q", statusLine)
+	}
+	code, _, _ := strings.Cut(status, " ")
+	statusCode, err := strconv.Atoi(code)
+	if err != nil {
+		return nil, fmt.Errorf("malformed API response status: %q", status)
+	}
+
+	mimeHeader, err := tp.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read API response headers: %w", err)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API response body: %w", err)
+	}
+
+	return &Response{StatusCode: statusCode, Header: http.Header(mimeHeader), Body: body}, nil
+}
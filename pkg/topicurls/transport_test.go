@@ -0,0 +1,64 @@
+package topicurls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		output         string
+		expectedStatus int
+		expectedETag   string
+		expectedBody   string
+		expectError    bool
+	}{
+		{
+			name:           "OK with headers and body",
+			output:         "HTTP/2.0 200 OK\r\nEtag: W/\"abc\"\r\nX-Ratelimit-Remaining: 59\r\n\r\n[{\"number\":1}]",
+			expectedStatus: 200,
+			expectedETag:   `W/"abc"`,
+			expectedBody:   `[{"number":1}]`,
+		},
+		{
+			name:           "Not modified without body",
+			output:         "HTTP/2.0 304 Not Modified\r\nEtag: \"abc\"\r\n\r\n",
+			expectedStatus: 304,
+			expectedETag:   `"abc"`,
+			expectedBody:   "",
+		},
+		{
+			name:        "Body without status line",
+			output:      `[{"number":1}]`,
+			expectError: true,
+		},
+		{
+			name:        "Empty output",
+			output:      "",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act: Parse output
+			resp, err := ParseResponse([]byte(tt.output))
+
+			// Assert: Verify status, headers and body
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedETag, resp.Header.Get("ETag"))
+			assert.Equal(t, tt.expectedBody, string(resp.Body))
+		})
+	}
+}